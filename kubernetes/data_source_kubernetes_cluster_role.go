@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rbac "k8s.io/kubernetes/pkg/apis/rbac/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesClusterRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesClusterRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("cluster role", false),
+			"rule":     policyRuleSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesClusterRoleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+
+	log.Printf("[INFO] Reading cluster role %s", name)
+	var role rbac.ClusterRole
+	err := getRbacObject(conn, "clusterroles", "", name, &role)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received cluster role: %#v", role)
+
+	err = d.Set("metadata", flattenMetadata(role.ObjectMeta))
+	if err != nil {
+		return err
+	}
+
+	return d.Set("rule", flattenPolicyRules(role.Rules))
+}
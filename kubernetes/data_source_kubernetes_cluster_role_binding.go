@@ -0,0 +1,48 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rbac "k8s.io/kubernetes/pkg/apis/rbac/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesClusterRoleBinding() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesClusterRoleBindingRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("cluster role binding", false),
+			"role_ref": roleRefSchema(),
+			"subject":  subjectSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesClusterRoleBindingRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+
+	log.Printf("[INFO] Reading cluster role binding %s", name)
+	var binding rbac.ClusterRoleBinding
+	err := getRbacObject(conn, "clusterrolebindings", "", name, &binding)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received cluster role binding: %#v", binding)
+
+	err = d.Set("metadata", flattenMetadata(binding.ObjectMeta))
+	if err != nil {
+		return err
+	}
+	err = d.Set("role_ref", flattenRoleRef(binding.RoleRef))
+	if err != nil {
+		return err
+	}
+
+	return d.Set("subject", flattenSubjects(binding.Subjects))
+}
@@ -0,0 +1,35 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceClusterRoleBinding_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceClusterRoleBindingConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding.test", "metadata.0.name", "cluster-admin"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding.test", "role_ref.0.kind", "ClusterRole"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding.test", "role_ref.0.name", "cluster-admin"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding.test", "subject.#", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding.test", "subject.0.kind", "Group"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role_binding.test", "subject.0.name", "system:masters"),
+				),
+			},
+		},
+	})
+}
+
+const testAccKubernetesDataSourceClusterRoleBindingConfig_basic = `
+data "kubernetes_cluster_role_binding" "test" {
+	metadata {
+		name = "cluster-admin"
+	}
+}
+`
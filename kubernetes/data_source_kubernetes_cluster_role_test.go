@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceClusterRole_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceClusterRoleConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "metadata.0.name", "cluster-admin"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_cluster_role.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "rule.0.verbs.0", "*"),
+					resource.TestCheckResourceAttr("data.kubernetes_cluster_role.test", "rule.0.resources.0", "*"),
+				),
+			},
+		},
+	})
+}
+
+const testAccKubernetesDataSourceClusterRoleConfig_basic = `
+data "kubernetes_cluster_role" "test" {
+	metadata {
+		name = "cluster-admin"
+	}
+}
+`
@@ -0,0 +1,137 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	batchv1 "k8s.io/kubernetes/pkg/apis/batch/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// cronJobGroupVersions lists API versions serving cron jobs, newest first.
+// The vendored client only knows batch/v2alpha1 which newer servers dropped.
+var cronJobGroupVersions = []string{"batch/v1", "batch/v1beta1", "batch/v2alpha1"}
+
+// cronJob holds the parts of a CronJob we care about
+type cronJob struct {
+	APIVersion string            `json:"apiVersion"`
+	Metadata   metav1.ObjectMeta `json:"metadata"`
+	Spec       struct {
+		Schedule    string `json:"schedule"`
+		Suspend     *bool  `json:"suspend"`
+		JobTemplate struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+			Spec     batchv1.JobSpec   `json:"spec"`
+		} `json:"jobTemplate"`
+	} `json:"spec"`
+	Status struct {
+		Active []struct {
+			Name string `json:"name"`
+		} `json:"active"`
+		LastScheduleTime *metav1.Time `json:"lastScheduleTime"`
+	} `json:"status"`
+}
+
+func dataSourceKubernetesCronJob() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesCronJobRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("cron job", false),
+			"schedule": {
+				Type:        schema.TypeString,
+				Description: "The schedule in Cron format.",
+				Computed:    true,
+			},
+			"suspend": {
+				Type:        schema.TypeBool,
+				Description: "Whether subsequent executions are suspended.",
+				Computed:    true,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Description: "Whether the cron job currently has running jobs.",
+				Computed:    true,
+			},
+			"active_jobs": {
+				Type:        schema.TypeList,
+				Description: "Names of the currently running jobs.",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"last_schedule_time": {
+				Type:        schema.TypeString,
+				Description: "When the job was last successfully scheduled (RFC 3339).",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesCronJobRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	d.SetId(buildId(metadata))
+
+	log.Printf("[INFO] Reading cron job %s", metadata.Name)
+	job, err := getCronJob(conn, metadata.Namespace, metadata.Name)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received cron job: %#v", job)
+
+	err = d.Set("metadata", flattenMetadata(job.Metadata))
+	if err != nil {
+		return err
+	}
+
+	activeJobs := make([]string, len(job.Status.Active))
+	for i, ref := range job.Status.Active {
+		activeJobs[i] = ref.Name
+	}
+	d.Set("schedule", job.Spec.Schedule)
+	d.Set("suspend", job.Spec.Suspend != nil && *job.Spec.Suspend)
+	d.Set("active", len(activeJobs) > 0)
+	d.Set("active_jobs", activeJobs)
+	if job.Status.LastScheduleTime != nil {
+		d.Set("last_schedule_time", job.Status.LastScheduleTime.UTC().Format(time.RFC3339))
+	} else {
+		d.Set("last_schedule_time", "")
+	}
+
+	return nil
+}
+
+// getCronJob fetches a cron job from the first API version the server serves
+func getCronJob(conn *kubernetes.Clientset, namespace, name string) (*cronJob, error) {
+	var err error
+	for _, gv := range cronJobGroupVersions {
+		var raw []byte
+		raw, err = conn.CoreV1().RESTClient().Get().
+			AbsPath("/apis", gv, "namespaces", namespace, "cronjobs", name).
+			Do().
+			Raw()
+		if err != nil {
+			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+				log.Printf("[DEBUG] Cron job %s/%s not found in %s", namespace, name, gv)
+				continue
+			}
+			return nil, err
+		}
+
+		var job cronJob
+		err = json.Unmarshal(raw, &job)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode cron job %s/%s: %s", namespace, name, err)
+		}
+		return &job, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,48 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestGetCronJob_fallback(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		// Only the oldest API version serves cron jobs
+		if r.URL.Path != "/apis/batch/v2alpha1/namespaces/default/cronjobs/backup" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+			return
+		}
+		fmt.Fprint(w, `{"kind":"CronJob","apiVersion":"batch/v2alpha1","metadata":{"name":"backup","namespace":"default"},"spec":{"schedule":"0 * * * *"},"status":{"active":[{"kind":"Job","name":"backup-1"}]}}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := getCronJob(conn, "default", "backup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 requests, got %q", requests)
+	}
+	if job.Spec.Schedule != "0 * * * *" || len(job.Status.Active) != 1 || job.Status.Active[0].Name != "backup-1" {
+		t.Fatalf("Unexpected cron job: %#v", job)
+	}
+
+	_, err = getCronJob(conn, "default", "missing")
+	if err == nil {
+		t.Fatal("Expected missing cron job to fail")
+	}
+}
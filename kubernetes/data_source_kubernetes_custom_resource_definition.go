@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// customResourceDefinition holds the parts of an apiextensions.k8s.io/v1beta1
+// CustomResourceDefinition we care about, the vendored client doesn't know the type
+type customResourceDefinition struct {
+	Metadata metav1.ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Scope   string `json:"scope"`
+		Names   struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []struct {
+			Type   string `json:"type"`
+			Status string `json:"status"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+func dataSourceKubernetesCustomResourceDefinition() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesCustomResourceDefinitionRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("custom resource definition", false),
+			"optional": {
+				Type:        schema.TypeBool,
+				Description: "Set `exists` to false instead of failing when the custom resource definition does not exist.",
+				Optional:    true,
+				Default:     false,
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Description: "Whether the custom resource definition exists.",
+				Computed:    true,
+			},
+			"established": {
+				Type:        schema.TypeBool,
+				Description: "Whether the API server accepts custom resources of this definition.",
+				Computed:    true,
+			},
+			"group": {
+				Type:        schema.TypeString,
+				Description: "API group of the custom resources.",
+				Computed:    true,
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Description: "Kind of the custom resources.",
+				Computed:    true,
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Description: "Whether the custom resources are `Namespaced` or `Cluster` scoped.",
+				Computed:    true,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Description: "API version of the custom resources.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesCustomResourceDefinitionRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+
+	log.Printf("[INFO] Reading custom resource definition %s", name)
+	raw, err := conn.CoreV1().RESTClient().Get().
+		AbsPath("/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions", name).
+		Do().
+		Raw()
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 && d.Get("optional").(bool) {
+			log.Printf("[INFO] Custom resource definition %s not found", name)
+			d.Set("exists", false)
+			d.Set("established", false)
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	var crd customResourceDefinition
+	err = json.Unmarshal(raw, &crd)
+	if err != nil {
+		return fmt.Errorf("Failed to decode custom resource definition %s: %s", name, err)
+	}
+	log.Printf("[INFO] Received custom resource definition: %#v", crd)
+
+	err = d.Set("metadata", flattenMetadata(crd.Metadata))
+	if err != nil {
+		return err
+	}
+
+	established := false
+	for _, c := range crd.Status.Conditions {
+		if c.Type == "Established" && c.Status == "True" {
+			established = true
+		}
+	}
+
+	d.Set("exists", true)
+	d.Set("established", established)
+	d.Set("group", crd.Spec.Group)
+	d.Set("kind", crd.Spec.Names.Kind)
+	d.Set("scope", crd.Spec.Scope)
+	d.Set("version", crd.Spec.Version)
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceCustomResourceDefinition_optional(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s.example.com", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceCustomResourceDefinitionConfig_missing(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_custom_resource_definition.test", "exists", "false"),
+					resource.TestCheckResourceAttr("data.kubernetes_custom_resource_definition.test", "established", "false"),
+				),
+			},
+			{
+				Config:      testAccKubernetesDataSourceCustomResourceDefinitionConfig_missing(name, false),
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceCustomResourceDefinitionConfig_missing(name string, optional bool) string {
+	return fmt.Sprintf(`
+data "kubernetes_custom_resource_definition" "test" {
+	metadata {
+		name = "%s"
+	}
+	optional = %t
+}
+`, name, optional)
+}
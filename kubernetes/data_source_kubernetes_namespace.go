@@ -0,0 +1,48 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceKubernetesNamespace() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesNamespaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadataSchema("namespace", false),
+			"optional": {
+				Type:        schema.TypeBool,
+				Description: "Set `exists` to false instead of failing when the namespace does not exist.",
+				Optional:    true,
+				Default:     false,
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Description: "Whether the namespace exists.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesNamespaceRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Get("metadata.0.name").(string)
+	d.SetId(name)
+
+	if d.Get("optional").(bool) {
+		exists, err := resourceKubernetesNamespaceExists(d, meta)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			log.Printf("[INFO] Namespace %s not found", name)
+			d.Set("exists", false)
+			return nil
+		}
+	}
+
+	d.Set("exists", true)
+	return resourceKubernetesNamespaceRead(d, meta)
+}
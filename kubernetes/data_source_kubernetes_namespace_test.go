@@ -0,0 +1,73 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceNamespace_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceNamespaceConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_namespace.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_namespace.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_namespace.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_namespace.test", "metadata.0.labels.TestLabelOne", "one"),
+					resource.TestCheckResourceAttr("data.kubernetes_namespace.test", "exists", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDataSourceNamespace_optional(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceNamespaceConfig_missing(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_namespace.test", "exists", "false"),
+				),
+			},
+			{
+				Config:      testAccKubernetesDataSourceNamespaceConfig_missing(name, false),
+				ExpectError: regexp.MustCompile("not found"),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceNamespaceConfig_basic(name string) string {
+	return testAccKubernetesNamespaceConfig_basic(name) + `
+data "kubernetes_namespace" "test" {
+	metadata {
+		name = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+}
+`
+}
+
+func testAccKubernetesDataSourceNamespaceConfig_missing(name string, optional bool) string {
+	return fmt.Sprintf(`
+data "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+	optional = %t
+}
+`, name, optional)
+}
@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesObjectStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesObjectStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "Kind of the object, e.g. `Deployment` or `Pod`.",
+				Required:     true,
+				ValidateFunc: validateAttributeValueIsIn(supportedObjectKinds()),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the object. Ignored for cluster-scoped kinds.",
+				Optional:    true,
+				Default:     "default",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the object.",
+				Required:    true,
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Description: "Whether the object exists. The other attributes are empty when it doesn't.",
+				Computed:    true,
+			},
+			"phase": {
+				Type:        schema.TypeString,
+				Description: "Phase of the object, e.g. `Running` for pods or `Bound` for persistent volume claims. Empty for kinds without a phase.",
+				Computed:    true,
+			},
+			"conditions": {
+				Type:        schema.TypeMap,
+				Description: "Status of the conditions of the object by type, e.g. `Available = \"True\"` for deployments.",
+				Computed:    true,
+			},
+			"replicas": {
+				Type:        schema.TypeInt,
+				Description: "Number of replicas observed by the controller of a workload, desired scheduled pods for daemon sets.",
+				Computed:    true,
+			},
+			"ready_replicas": {
+				Type:        schema.TypeInt,
+				Description: "Number of ready replicas of a workload, ready scheduled pods for daemon sets.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesObjectStatusRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind := d.Get("kind").(string)
+	namespace := d.Get("namespace").(string)
+	name := d.Get("name").(string)
+	if k, ok := objectKinds[kind]; ok && !k.namespaced {
+		namespace = ""
+	}
+	d.SetId(buildObjectId(kind, namespace, name))
+
+	log.Printf("[INFO] Reading status of %s %s", kind, name)
+	status, err := getObjectStatus(conn, kind, namespace, name)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			log.Printf("[INFO] %s %s not found", kind, name)
+			d.Set("exists", false)
+			d.Set("phase", "")
+			d.Set("conditions", map[string]string{})
+			d.Set("replicas", 0)
+			d.Set("ready_replicas", 0)
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received status: %#v", status)
+
+	d.Set("exists", true)
+	d.Set("phase", status.Phase)
+	d.Set("conditions", status.Conditions)
+	d.Set("replicas", status.Replicas)
+	d.Set("ready_replicas", status.ReadyReplicas)
+
+	return nil
+}
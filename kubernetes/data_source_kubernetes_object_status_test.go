@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestGetObjectStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/extensions/v1beta1/namespaces/default/deployments/web":
+			fmt.Fprint(w, `{"kind":"Deployment","metadata":{"name":"web"},"status":{"replicas":3,"readyReplicas":2,`+
+				`"conditions":[{"type":"Available","status":"False"},{"type":"Progressing","status":"True"}]}}`)
+		case "/apis/extensions/v1beta1/namespaces/default/daemonsets/agent":
+			fmt.Fprint(w, `{"kind":"DaemonSet","metadata":{"name":"agent"},"status":{"desiredNumberScheduled":4,"numberReady":4}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		}
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := getObjectStatus(conn, "Deployment", "default", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Replicas != 3 || status.ReadyReplicas != 2 {
+		t.Fatalf("Unexpected replicas: %#v", status)
+	}
+	if status.Conditions["Available"] != "False" || status.Conditions["Progressing"] != "True" {
+		t.Fatalf("Unexpected conditions: %#v", status.Conditions)
+	}
+
+	status, err = getObjectStatus(conn, "DaemonSet", "default", "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Replicas != 4 || status.ReadyReplicas != 4 {
+		t.Fatalf("Unexpected daemon set replicas: %#v", status)
+	}
+
+	_, err = getObjectStatus(conn, "Deployment", "default", "missing")
+	if err == nil {
+		t.Fatal("Expected missing deployment to fail")
+	}
+}
+
+func TestAccKubernetesDataSourceObjectStatus_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceObjectStatusConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_object_status.test", "exists", "true"),
+					resource.TestCheckResourceAttr("data.kubernetes_object_status.test", "phase", "Active"),
+					resource.TestCheckResourceAttr("data.kubernetes_object_status.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.kubernetes_object_status.missing", "conditions.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceObjectStatusConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+data "kubernetes_object_status" "test" {
+	kind = "Namespace"
+	name = "${kubernetes_namespace.test.metadata.0.name}"
+}
+
+data "kubernetes_object_status" "missing" {
+	kind = "ConfigMap"
+	namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	name = "missing"
+}
+`, name)
+}
@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesObjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesObjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "Kind of the objects to search for, e.g. `Pod` or `Service`.",
+				Required:     true,
+				ValidateFunc: validateAttributeValueIsIn(supportedObjectKinds()),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace to search in. Searches all namespaces when empty. Ignored for cluster-scoped kinds.",
+				Optional:    true,
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Description: "A label query over the objects, e.g. `app=web,tier!=cache`. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+				Optional:    true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of objects to return. No limit when 0.",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateNonNegativeInteger,
+			},
+			"objects": {
+				Type:        schema.TypeList,
+				Description: "Identities of the matching objects.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"namespace": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesObjectsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind := d.Get("kind").(string)
+	namespace := d.Get("namespace").(string)
+	selector := d.Get("label_selector").(string)
+
+	log.Printf("[INFO] Searching for %s objects in namespace %q matching %q", kind, namespace, selector)
+	metas, err := listObjectMetas(conn, kind, namespace, selector, d.Get("limit").(int))
+	if err != nil {
+		return wrapError(err, "Failed to list %s objects", kind)
+	}
+
+	objects := make([]interface{}, len(metas))
+	for i, m := range metas {
+		objects[i] = map[string]interface{}{
+			"namespace": m.Namespace,
+			"name":      m.Name,
+			"uid":       string(m.UID),
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s?%s", kind, namespace, selector))
+	return d.Set("objects", objects)
+}
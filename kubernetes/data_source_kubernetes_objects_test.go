@@ -0,0 +1,54 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceObjects_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceObjectsConfig_basic(name, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_objects.test", "objects.#", "3"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_objects.test", "objects.0.uid"),
+				),
+			},
+			{
+				Config: testAccKubernetesDataSourceObjectsConfig_basic(name, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_objects.test", "objects.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceObjectsConfig_basic(name string, limit int) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+	count = 3
+	metadata {
+		name = "%s-${count.index}"
+		labels {
+			TestSearch = "%s"
+		}
+	}
+}
+
+data "kubernetes_objects" "test" {
+	kind = "ConfigMap"
+	label_selector = "TestSearch=${kubernetes_config_map.test.0.metadata.0.labels.TestSearch}"
+	limit = %d
+	depends_on = ["kubernetes_config_map.test"]
+}
+`, name, name, limit)
+}
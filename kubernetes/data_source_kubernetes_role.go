@@ -0,0 +1,43 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rbac "k8s.io/kubernetes/pkg/apis/rbac/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesRole() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesRoleRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("role", false),
+			"rule":     policyRuleSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesRoleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	d.SetId(buildId(metadata))
+
+	log.Printf("[INFO] Reading role %s", metadata.Name)
+	var role rbac.Role
+	err := getRbacObject(conn, "roles", metadata.Namespace, metadata.Name, &role)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received role: %#v", role)
+
+	err = d.Set("metadata", flattenMetadata(role.ObjectMeta))
+	if err != nil {
+		return err
+	}
+
+	return d.Set("rule", flattenPolicyRules(role.Rules))
+}
@@ -0,0 +1,48 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rbac "k8s.io/kubernetes/pkg/apis/rbac/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesRoleBinding() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesRoleBindingRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("role binding", false),
+			"role_ref": roleRefSchema(),
+			"subject":  subjectSchema(),
+		},
+	}
+}
+
+func dataSourceKubernetesRoleBindingRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	d.SetId(buildId(metadata))
+
+	log.Printf("[INFO] Reading role binding %s", metadata.Name)
+	var binding rbac.RoleBinding
+	err := getRbacObject(conn, "rolebindings", metadata.Namespace, metadata.Name, &binding)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received role binding: %#v", binding)
+
+	err = d.Set("metadata", flattenMetadata(binding.ObjectMeta))
+	if err != nil {
+		return err
+	}
+	err = d.Set("role_ref", flattenRoleRef(binding.RoleRef))
+	if err != nil {
+		return err
+	}
+
+	return d.Set("subject", flattenSubjects(binding.Subjects))
+}
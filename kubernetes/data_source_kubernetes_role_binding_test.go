@@ -0,0 +1,34 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceRoleBinding_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceRoleBindingConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_role_binding.test", "metadata.0.name", "system::extension-apiserver-authentication-reader"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_binding.test", "role_ref.0.kind", "Role"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_binding.test", "role_ref.0.name", "extension-apiserver-authentication-reader"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_role_binding.test", "subject.0.name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccKubernetesDataSourceRoleBindingConfig_basic = `
+data "kubernetes_role_binding" "test" {
+	metadata {
+		name      = "system::extension-apiserver-authentication-reader"
+		namespace = "kube-system"
+	}
+}
+`
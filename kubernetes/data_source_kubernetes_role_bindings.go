@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	rbac "k8s.io/kubernetes/pkg/apis/rbac/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesRoleBindings() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesRoleBindingsRead,
+
+		Schema: map[string]*schema.Schema{
+			"role_kind": {
+				Type:         schema.TypeString,
+				Description:  "Only return bindings granting a role of this kind, `Role` or `ClusterRole`.",
+				Optional:     true,
+				ValidateFunc: validateAttributeValueIsIn([]string{"Role", "ClusterRole"}),
+			},
+			"role_name": {
+				Type:        schema.TypeString,
+				Description: "Only return bindings granting the role of this name, e.g. `cluster-admin`.",
+				Optional:    true,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Only return role bindings of this namespace. Cluster role bindings and role bindings of all namespaces are returned when empty.",
+				Optional:    true,
+			},
+			"binding": {
+				Type:        schema.TypeList,
+				Description: "The matching bindings.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kind": {
+							Type:        schema.TypeString,
+							Description: "Kind of the binding, `RoleBinding` or `ClusterRoleBinding`.",
+							Computed:    true,
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Description: "Namespace of the role binding. Empty for cluster role bindings.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the binding.",
+							Computed:    true,
+						},
+						"role_ref": roleRefSchema(),
+						"subject":  subjectSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesRoleBindingsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace := d.Get("namespace").(string)
+	roleKind := d.Get("role_kind").(string)
+	roleName := d.Get("role_name").(string)
+
+	matches := func(ref rbac.RoleRef) bool {
+		return (roleKind == "" || ref.Kind == roleKind) && (roleName == "" || ref.Name == roleName)
+	}
+
+	bindings := make([]interface{}, 0)
+	if namespace == "" {
+		log.Printf("[INFO] Listing cluster role bindings")
+		var list rbac.ClusterRoleBindingList
+		err := listRbacObjects(conn, "clusterrolebindings", "", &list)
+		if err != nil {
+			return wrapError(err, "Failed to list cluster role bindings")
+		}
+		for _, b := range list.Items {
+			if !matches(b.RoleRef) {
+				continue
+			}
+			bindings = append(bindings, map[string]interface{}{
+				"kind":      "ClusterRoleBinding",
+				"namespace": "",
+				"name":      b.Name,
+				"role_ref":  flattenRoleRef(b.RoleRef),
+				"subject":   flattenSubjects(b.Subjects),
+			})
+		}
+	}
+
+	log.Printf("[INFO] Listing role bindings in namespace %q", namespace)
+	var list rbac.RoleBindingList
+	err := listRbacObjects(conn, "rolebindings", namespace, &list)
+	if err != nil {
+		return wrapError(err, "Failed to list role bindings")
+	}
+	for _, b := range list.Items {
+		if !matches(b.RoleRef) {
+			continue
+		}
+		bindings = append(bindings, map[string]interface{}{
+			"kind":      "RoleBinding",
+			"namespace": b.Namespace,
+			"name":      b.Name,
+			"role_ref":  flattenRoleRef(b.RoleRef),
+			"subject":   flattenSubjects(b.Subjects),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", namespace, roleKind, roleName))
+	return d.Set("binding", bindings)
+}
@@ -0,0 +1,129 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	restclient "k8s.io/client-go/rest"
+	rbac "k8s.io/kubernetes/pkg/apis/rbac/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestListRbacObjects_fallback(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		// Only the older API version is served
+		if r.URL.Path != "/apis/rbac.authorization.k8s.io/v1beta1/clusterrolebindings" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+			return
+		}
+		fmt.Fprint(w, `{"kind":"ClusterRoleBindingList","apiVersion":"rbac.authorization.k8s.io/v1beta1","items":[`+
+			`{"metadata":{"name":"cluster-admin"},"roleRef":{"apiGroup":"rbac.authorization.k8s.io","kind":"ClusterRole","name":"cluster-admin"},`+
+			`"subjects":[{"kind":"Group","apiGroup":"rbac.authorization.k8s.io","name":"system:masters"}]}]}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list rbac.ClusterRoleBindingList
+	err = listRbacObjects(conn, "clusterrolebindings", "", &list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %q", requests)
+	}
+	if len(list.Items) != 1 || list.Items[0].RoleRef.Name != "cluster-admin" {
+		t.Fatalf("Unexpected cluster role bindings: %#v", list)
+	}
+	subjects := flattenSubjects(list.Items[0].Subjects)
+	if len(subjects) != 1 || subjects[0].(map[string]interface{})["name"] != "system:masters" {
+		t.Fatalf("Unexpected subjects: %#v", subjects)
+	}
+
+	var binding rbac.RoleBinding
+	err = getRbacObject(conn, "rolebindings", "default", "missing", &binding)
+	if err == nil {
+		t.Fatal("Expected missing role binding to fail")
+	}
+}
+
+func TestListRbacObjects_chunked(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RequestURI())
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/apis/rbac.authorization.k8s.io/v1/rolebindings" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+			return
+		}
+		if r.URL.Query().Get("limit") != strconv.Itoa(objectListPageSize) {
+			t.Errorf("Unexpected limit in %q", r.URL.RequestURI())
+		}
+		item := `{"metadata":{"name":%q,"namespace":"default"},"roleRef":{"apiGroup":"rbac.authorization.k8s.io","kind":"Role","name":"view"}}`
+		if r.URL.Query().Get("continue") == "" {
+			fmt.Fprintf(w, `{"kind":"RoleBindingList","metadata":{"continue":"next"},"items":[`+item+`]}`, "first")
+			return
+		}
+		fmt.Fprintf(w, `{"kind":"RoleBindingList","metadata":{},"items":[`+item+`,`+item+`]}`, "second", "third")
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list rbac.RoleBindingList
+	err = listRbacObjects(conn, "rolebindings", "", &list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || !strings.Contains(requests[1], "continue=next") {
+		t.Fatalf("Expected 2 chunked requests, got %q", requests)
+	}
+	names := make([]string, 0)
+	for _, b := range list.Items {
+		names = append(names, b.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"first", "second", "third"}) {
+		t.Fatalf("Unexpected role bindings: %q", names)
+	}
+}
+
+func TestAccKubernetesDataSourceRoleBindings_clusterAdmin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceRoleBindingsConfig_clusterAdmin,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.kubernetes_role_bindings.test", "binding.#"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_bindings.test", "binding.0.kind", "ClusterRoleBinding"),
+					resource.TestCheckResourceAttr("data.kubernetes_role_bindings.test", "binding.0.role_ref.0.name", "cluster-admin"),
+				),
+			},
+		},
+	})
+}
+
+const testAccKubernetesDataSourceRoleBindingsConfig_clusterAdmin = `
+data "kubernetes_role_bindings" "test" {
+	role_kind = "ClusterRole"
+	role_name = "cluster-admin"
+}
+`
@@ -0,0 +1,34 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceRole_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceRoleConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_role.test", "metadata.0.name", "extension-apiserver-authentication-reader"),
+					resource.TestCheckResourceAttr("data.kubernetes_role.test", "metadata.0.namespace", "kube-system"),
+					resource.TestCheckResourceAttr("data.kubernetes_role.test", "rule.0.resources.0", "configmaps"),
+					resource.TestCheckResourceAttr("data.kubernetes_role.test", "rule.0.resource_names.0", "extension-apiserver-authentication"),
+				),
+			},
+		},
+	})
+}
+
+const testAccKubernetesDataSourceRoleConfig_basic = `
+data "kubernetes_role" "test" {
+	metadata {
+		name      = "extension-apiserver-authentication-reader"
+		namespace = "kube-system"
+	}
+}
+`
@@ -0,0 +1,103 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func dataSourceKubernetesSecret() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKubernetesSecretRead,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("secret", false),
+			"keys": {
+				Type:        schema.TypeSet,
+				Description: "Restrict `data` to these keys. All keys are returned when not specified.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"optional": {
+				Type:        schema.TypeBool,
+				Description: "Set `exists` to false and return empty `data` instead of failing when the secret does not exist, and omit missing `keys` from `data`.",
+				Optional:    true,
+				Default:     false,
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Description: "Whether the secret exists.",
+				Computed:    true,
+			},
+			"data": {
+				Type:        schema.TypeMap,
+				Description: "A map of the secret data.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Description: "Type of secret",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesSecretRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	om := meta_v1.ObjectMeta{
+		Namespace: d.Get("metadata.0.namespace").(string),
+		Name:      d.Get("metadata.0.name").(string),
+	}
+	d.SetId(buildId(om))
+	optional := d.Get("optional").(bool)
+
+	log.Printf("[INFO] Reading secret %s", om.Name)
+	secret, err := conn.CoreV1().Secrets(om.Namespace).Get(om.Name, meta_v1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 && optional {
+			log.Printf("[INFO] Secret %s not found, returning empty data", om.Name)
+			d.Set("exists", false)
+			d.Set("data", map[string]string{})
+			d.Set("type", "")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received secret: %#v", secret)
+
+	err = d.Set("metadata", flattenMetadata(secret.ObjectMeta))
+	if err != nil {
+		return err
+	}
+
+	data := byteMapToStringMap(secret.Data)
+	if v, ok := d.GetOk("keys"); ok {
+		filtered := make(map[string]string, 0)
+		for _, k := range sliceOfString(v.(*schema.Set).List()) {
+			value, ok := data[k]
+			if !ok {
+				if optional {
+					continue
+				}
+				return fmt.Errorf("Key %q not found in secret %s", k, buildId(secret.ObjectMeta))
+			}
+			filtered[k] = value
+		}
+		data = filtered
+	}
+
+	d.Set("exists", true)
+	d.Set("data", data)
+	d.Set("type", secret.Type)
+
+	return nil
+}
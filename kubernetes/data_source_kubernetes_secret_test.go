@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesDataSourceSecret_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceSecretConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("data.kubernetes_secret.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("data.kubernetes_secret.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "data.%", "2"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "data.one", "first"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "data.two", "second"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "type", "Opaque"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "exists", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDataSourceSecret_keys(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceSecretConfig_keys(name, `"one", "nine"`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "data.%", "1"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "data.one", "first"),
+				),
+			},
+			{
+				Config:      testAccKubernetesDataSourceSecretConfig_keys(name, `"one", "nine"`, false),
+				ExpectError: regexp.MustCompile(`Key "nine" not found in secret`),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDataSourceSecret_optionalMissing(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceSecretConfig_missing(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "exists", "false"),
+					resource.TestCheckResourceAttr("data.kubernetes_secret.test", "data.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDataSourceSecretConfig_basic(name string) string {
+	return testAccKubernetesSecretConfig_basic(name) + `
+data "kubernetes_secret" "test" {
+	metadata {
+		name = "${kubernetes_secret.test.metadata.0.name}"
+	}
+}
+`
+}
+
+func testAccKubernetesDataSourceSecretConfig_keys(name, keys string, optional bool) string {
+	return testAccKubernetesSecretConfig_basic(name) + fmt.Sprintf(`
+data "kubernetes_secret" "test" {
+	metadata {
+		name = "${kubernetes_secret.test.metadata.0.name}"
+	}
+	keys = [%s]
+	optional = %t
+}
+`, keys, optional)
+}
+
+func testAccKubernetesDataSourceSecretConfig_missing(name string) string {
+	return fmt.Sprintf(`
+data "kubernetes_secret" "test" {
+	metadata {
+		name = "%s"
+	}
+	optional = true
+}
+`, name)
+}
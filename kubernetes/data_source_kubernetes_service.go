@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"log"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -11,6 +13,17 @@ func dataSourceKubernetesService() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("service", false),
+			"optional": {
+				Type:        schema.TypeBool,
+				Description: "Set `exists` to false instead of failing when the service does not exist.",
+				Optional:    true,
+				Default:     false,
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Description: "Whether the service exists.",
+				Computed:    true,
+			},
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the behavior of a service. https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
@@ -127,5 +140,18 @@ func dataSourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) e
 	}
 	d.SetId(buildId(om))
 
+	if d.Get("optional").(bool) {
+		exists, err := resourceKubernetesServiceExists(d, meta)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			log.Printf("[INFO] Service %s not found", om.Name)
+			d.Set("exists", false)
+			return nil
+		}
+	}
+
+	d.Set("exists", true)
 	return resourceKubernetesServiceRead(d, meta)
 }
@@ -33,6 +33,24 @@ func TestAccKubernetesDataSourceService_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("data.kubernetes_service.test", "spec.0.port.0.target_port", "80"),
 					resource.TestCheckResourceAttr("data.kubernetes_service.test", "spec.0.session_affinity", "None"),
 					resource.TestCheckResourceAttr("data.kubernetes_service.test", "spec.0.type", "ClusterIP"),
+					resource.TestCheckResourceAttr("data.kubernetes_service.test", "exists", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesDataSourceService_optional(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDataSourceServiceConfig_missing(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.kubernetes_service.test", "exists", "false"),
 				),
 			},
 		},
@@ -48,3 +66,14 @@ data "kubernetes_service" "test" {
 }
 `
 }
+
+func testAccKubernetesDataSourceServiceConfig_missing(name string) string {
+	return fmt.Sprintf(`
+data "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	optional = true
+}
+`, name)
+}
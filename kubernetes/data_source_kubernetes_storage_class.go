@@ -9,6 +9,11 @@ func dataSourceKubernetesStorageClass() *schema.Resource {
 		Read: dataSourceKubernetesStorageClassRead,
 		Schema: map[string]*schema.Schema{
 			"metadata": metadataSchema("storage class", false),
+			"is_default_class": {
+				Type:        schema.TypeBool,
+				Description: "Whether this is the default storage class for claims that don't request any particular class.",
+				Computed:    true,
+			},
 			"parameters": {
 				Type:        schema.TypeMap,
 				Description: "The parameters for the provisioner that should create volumes of this storage class",
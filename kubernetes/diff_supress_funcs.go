@@ -1,6 +1,9 @@
 package kubernetes
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
@@ -16,3 +19,44 @@ func suppressEquivalentResourceQuantity(k, old, new string, d *schema.ResourceDa
 	}
 	return oldQ.Cmp(newQ) == 0
 }
+
+// suppressNodeSelectorSetByOs hides the node selector which is set from
+// the os field of a pod spec, as it isn't part of node_selector in the config
+func suppressNodeSelectorSetByOs(k, old, new string, d *schema.ResourceData) bool {
+	i := strings.LastIndex(k, "node_selector.")
+	if i < 0 {
+		return false
+	}
+	prefix, key := k[:i], k[i+len("node_selector."):]
+	if d.Get(prefix+"os").(string) == "" {
+		return false
+	}
+	if _, ok := d.Get(prefix + "node_selector").(map[string]interface{})[osNodeSelectorKey]; ok {
+		return false
+	}
+
+	switch key {
+	case osNodeSelectorKey:
+		return new == ""
+	case "%":
+		o, _ := d.GetChange(prefix + "node_selector")
+		if _, ok := o.(map[string]interface{})[osNodeSelectorKey]; !ok {
+			return false
+		}
+		oldCount, _ := strconv.Atoi(old)
+		newCount, _ := strconv.Atoi(new)
+		return oldCount == newCount+1
+	}
+	return false
+}
+
+// suppressOsSetByNodeSelector hides the os read back from a node selector
+// which is set in the config directly instead of through the os field
+func suppressOsSetByNodeSelector(k, old, new string, d *schema.ResourceData) bool {
+	if new != "" {
+		return false
+	}
+	prefix := strings.TrimSuffix(k, "os")
+	v, ok := d.Get(prefix + "node_selector").(map[string]interface{})[osNodeSelectorKey]
+	return ok && v == old
+}
@@ -0,0 +1,118 @@
+package kubernetes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Error codes prefixed to errors returned by resources and data sources
+// (e.g. "[Forbidden] ..."), so wrapper tooling can branch on the class
+// of failure instead of matching messages. These are part of the interface,
+// don't rename them.
+const (
+	errorCodeNotFound      = "NotFound"
+	errorCodeForbidden     = "Forbidden"
+	errorCodeConflict      = "Conflict"
+	errorCodeWebhookDenied = "WebhookDenied"
+	errorCodeTimeout       = "Timeout"
+)
+
+// codedError is an error annotated with an error code
+type codedError struct {
+	code string
+	err  error
+}
+
+func (e *codedError) Error() string {
+	return fmt.Sprintf("[%s] %s", e.code, e.err)
+}
+
+// wrappedError adds context to an error while keeping it
+// as the cause, so that the error can still be classified
+type wrappedError struct {
+	msg   string
+	cause error
+}
+
+func (e *wrappedError) Error() string {
+	return e.msg
+}
+
+// wrapError prefixes err with a message like fmt.Errorf("...: %s", err) does,
+// use it instead for errors returned by the API
+func wrapError(err error, format string, a ...interface{}) error {
+	return &wrappedError{
+		msg:   fmt.Sprintf(format, a...) + ": " + err.Error(),
+		cause: err,
+	}
+}
+
+// errorCode classifies an error, returning an empty string
+// for errors which don't fall into any of the known classes
+func errorCode(err error) string {
+	switch e := err.(type) {
+	case nil:
+		return ""
+	case *codedError:
+		return e.code
+	case *wrappedError:
+		return errorCode(e.cause)
+	case *resource.TimeoutError:
+		return errorCodeTimeout
+	}
+
+	// Admission webhooks reject requests with various status codes,
+	// only the message tells them apart
+	if strings.Contains(err.Error(), "admission webhook") && strings.Contains(err.Error(), "denied the request") {
+		return errorCodeWebhookDenied
+	}
+
+	switch {
+	case errors.IsNotFound(err):
+		return errorCodeNotFound
+	case errors.IsForbidden(err):
+		return errorCodeForbidden
+	case errors.IsConflict(err), errors.IsAlreadyExists(err):
+		return errorCodeConflict
+	case errors.IsTimeout(err), errors.IsServerTimeout(err):
+		return errorCodeTimeout
+	}
+	return ""
+}
+
+func withErrorCode(err error) error {
+	code := errorCode(err)
+	if code == "" {
+		return err
+	}
+	if _, ok := err.(*codedError); ok {
+		return err
+	}
+	return &codedError{code: code, err: err}
+}
+
+// withErrorCodes makes all operations of the resource return coded errors
+func withErrorCodes(r *schema.Resource) {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			return withErrorCode(f(d, meta))
+		}
+	}
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+	if exists := r.Exists; exists != nil {
+		r.Exists = func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			ok, err := exists(d, meta)
+			return ok, withErrorCode(err)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestErrorCode(t *testing.T) {
+	pods := k8sschema.GroupResource{Resource: "pods"}
+	cases := []struct {
+		Err      error
+		Expected string
+	}{
+		{nil, ""},
+		{errors.New("something went wrong"), ""},
+		{apierrors.NewBadRequest("invalid"), ""},
+		{apierrors.NewNotFound(pods, "web"), errorCodeNotFound},
+		{apierrors.NewForbidden(pods, "web", errors.New("denied")), errorCodeForbidden},
+		{apierrors.NewConflict(pods, "web", errors.New("modified")), errorCodeConflict},
+		{apierrors.NewAlreadyExists(pods, "web"), errorCodeConflict},
+		{apierrors.NewTimeoutError("too slow", 1), errorCodeTimeout},
+		{apierrors.NewServerTimeout(pods, "create", 1), errorCodeTimeout},
+		{&resource.TimeoutError{}, errorCodeTimeout},
+		{apierrors.NewBadRequest(`admission webhook "policy.example.com" denied the request: no latest tags`), errorCodeWebhookDenied},
+		{fmt.Errorf("Failed to update: %s", `admission webhook "policy.example.com" denied the request`), errorCodeWebhookDenied},
+		{wrapError(apierrors.NewConflict(pods, "web", errors.New("modified")), "Failed to update pod"), errorCodeConflict},
+		{wrapError(errors.New("connection refused"), "Failed to update pod"), ""},
+	}
+	for i, tc := range cases {
+		if code := errorCode(tc.Err); code != tc.Expected {
+			t.Fatalf("%d: expected %q, got %q for %#v", i, tc.Expected, code, tc.Err)
+		}
+	}
+}
+
+func TestWithErrorCodes(t *testing.T) {
+	r := &schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return apierrors.NewNotFound(k8sschema.GroupResource{Resource: "pods"}, "web")
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return errors.New("unclassified")
+		},
+	}
+	withErrorCodes(r)
+
+	coded := r.Read(nil, nil)
+	if coded == nil || coded.Error() != `[NotFound] pods "web" not found` {
+		t.Fatalf("Expected coded error, got %v", coded)
+	}
+	if withErrorCode(coded) != coded {
+		t.Fatal("Expected error codes not to be applied twice")
+	}
+	err := r.Delete(nil, nil)
+	if err == nil || err.Error() != "unclassified" {
+		t.Fatalf("Expected unclassified error to be left alone, got %v", err)
+	}
+	if r.Create != nil || r.Update != nil || r.Exists != nil {
+		t.Fatal("Expected missing operations to stay nil")
+	}
+}
+
+func TestWithErrorCodes_wrappedConflict(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"limitranges \"limits\" already exists","reason":"AlreadyExists","code":409}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := Provider().(*schema.Provider).ResourcesMap["kubernetes_limit_range"]
+	d := r.TestResourceData()
+	d.Set("metadata", []interface{}{map[string]interface{}{"name": "limits", "namespace": "default"}})
+
+	err = r.Create(d, conn)
+	if err == nil || !strings.HasPrefix(err.Error(), "[Conflict] Failed to create limit range: ") {
+		t.Fatalf("Expected the wrapped conflict to be coded, got %v", err)
+	}
+}
+
+func TestWithErrorCodes_wrappedCreateConflicts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","message":"the object has been modified","reason":"Conflict","code":409}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Resource string
+		Values   map[string]interface{}
+		Expected string
+	}{
+		{
+			"kubernetes_config_map_data",
+			map[string]interface{}{"name": "settings", "data": map[string]interface{}{"mode": "fast"}},
+			`[Conflict] Failed to set data of ConfigMap "settings": `,
+		},
+		{
+			"kubernetes_labels",
+			map[string]interface{}{"kind": "Namespace", "name": "apps", "labels": map[string]interface{}{"team": "web"}},
+			`[Conflict] Failed to set labels of Namespace "apps": `,
+		},
+	}
+	for _, tc := range cases {
+		r := Provider().(*schema.Provider).ResourcesMap[tc.Resource]
+		d := r.TestResourceData()
+		for k, v := range tc.Values {
+			d.Set(k, v)
+		}
+
+		err = r.Create(d, conn)
+		if err == nil || !strings.HasPrefix(err.Error(), tc.Expected) {
+			t.Fatalf("%s: expected the wrapped conflict to be coded, got %v", tc.Resource, err)
+		}
+	}
+}
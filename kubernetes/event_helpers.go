@@ -65,3 +65,18 @@ func stringifyEvents(events []api.Event) string {
 	}
 	return output
 }
+
+// waitWithEvents runs the given wait and, if it fails (e.g. times out),
+// attaches the latest warning events of the object to the error
+func waitWithEvents(conn *kubernetes.Clientset, metadata meta_v1.ObjectMeta, kind string, wait func() error) error {
+	err := wait()
+	if err == nil {
+		return nil
+	}
+	lastWarnings, wErr := getLastWarningsForObject(conn, metadata, kind, 3)
+	if wErr != nil {
+		log.Printf("[WARN] Failed to look up events of %s %s/%s: %s", kind, metadata.Namespace, metadata.Name, wErr)
+		return err
+	}
+	return &wrappedError{msg: err.Error() + stringifyEvents(lastWarnings), cause: err}
+}
@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestWaitWithEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/v1/namespaces/default/events" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+			return
+		}
+		fmt.Fprint(w, `{"kind":"EventList","apiVersion":"v1","items":[`+
+			`{"metadata":{"name":"a"},"type":"Normal","reason":"Scheduled","message":"Assigned"},`+
+			`{"metadata":{"name":"b"},"type":"Warning","reason":"ProvisioningFailed","message":"no storage class"}]}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata := meta_v1.ObjectMeta{Namespace: "default", Name: "data"}
+
+	err = waitWithEvents(conn, metadata, "PersistentVolumeClaim", func() error { return nil })
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	err = waitWithEvents(conn, metadata, "PersistentVolumeClaim", func() error {
+		return errors.New("timeout while waiting for state to become 'Bound'")
+	})
+	expected := "timeout while waiting for state to become 'Bound'\n   * ProvisioningFailed: no storage class"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected %q, given %v", expected, err)
+	}
+
+	// Failing to look up events keeps the original error
+	metadata.Namespace = "other"
+	err = waitWithEvents(conn, metadata, "PersistentVolumeClaim", func() error {
+		return errors.New("timeout")
+	})
+	if err == nil || err.Error() != "timeout" {
+		t.Fatalf("Expected the original error, given %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// checkNamespaceAllowed fails if the provider restricts namespaces
+// and the given one isn't among them
+func checkNamespaceAllowed(meta interface{}, namespace string) error {
+	allowed := getProviderOptions(meta).allowedNamespaces
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for _, ns := range allowed {
+		if ns == namespace {
+			return nil
+		}
+	}
+	return &codedError{
+		code: errorCodeForbidden,
+		err:  fmt.Errorf("Refusing to manage objects in namespace %q, it's not in allowed_namespaces of the provider %q", namespace, allowed),
+	}
+}
+
+// resourceNamespaceFunc returns a function reading the namespace the resource
+// is managing objects in, or nil for resources managing cluster-scoped objects
+func resourceNamespaceFunc(name string, r *schema.Resource) func(*schema.ResourceData) string {
+	if name == "kubernetes_namespace" {
+		return func(d *schema.ResourceData) string {
+			return d.Get("metadata.0.name").(string)
+		}
+	}
+
+	if s, ok := r.Schema["metadata"]; ok {
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			if _, ok := elem.Schema["namespace"]; ok {
+				return func(d *schema.ResourceData) string {
+					return d.Get("metadata.0.namespace").(string)
+				}
+			}
+		}
+		return nil
+	}
+
+	if _, ok := r.Schema["namespace"]; !ok {
+		return nil
+	}
+	if _, ok := r.Schema["kind"]; !ok {
+		return func(d *schema.ResourceData) string {
+			return d.Get("namespace").(string)
+		}
+	}
+	// Resources managing objects of any kind
+	return func(d *schema.ResourceData) string {
+		kind := d.Get("kind").(string)
+		if kind == "Namespace" {
+			return d.Get("name").(string)
+		}
+		if k, ok := objectKinds[kind]; !ok || !k.namespaced {
+			return ""
+		}
+		if ns := d.Get("namespace").(string); ns != "" {
+			return ns
+		}
+		return "default"
+	}
+}
+
+// withNamespaceGuard makes the resource refuse to create or update objects
+// in namespaces outside of allowed_namespaces, failing on apply before any
+// request is made. Without CustomizeDiff the plan can't be rejected.
+// Objects already in state can still be refreshed and destroyed, so that
+// they can be cleaned up once their namespace is no longer allowed.
+func withNamespaceGuard(name string, r *schema.Resource) {
+	namespace := resourceNamespaceFunc(name, r)
+	if namespace == nil {
+		return
+	}
+
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, meta interface{}) error {
+			// The namespace isn't known yet when importing
+			if ns := namespace(d); ns != "" {
+				if err := checkNamespaceAllowed(meta, ns); err != nil {
+					return err
+				}
+			}
+			return f(d, meta)
+		}
+	}
+	r.Create = wrap(r.Create)
+	r.Update = wrap(r.Update)
+}
@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestWithNamespaceGuard(t *testing.T) {
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: "http://localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setProviderOptions(conn, providerOptions{allowedNamespaces: []string{"team-a"}})
+	defer setProviderOptions(conn, providerOptions{})
+
+	created := false
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("pod", true),
+		},
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			created = true
+			return nil
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}
+	withNamespaceGuard("kubernetes_pod", r)
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "web", "namespace": "team-b"}},
+	})
+	err = r.Create(d, conn)
+	if err == nil || created {
+		t.Fatal("Expected namespace outside of allowed_namespaces to be rejected")
+	}
+	if errorCode(err) != errorCodeForbidden {
+		t.Fatalf("Expected %s error, got %q", errorCodeForbidden, err)
+	}
+
+	// Objects left in namespaces which are no longer allowed can be cleaned up
+	err = r.Delete(d, conn)
+	if err != nil {
+		t.Fatalf("Expected objects outside of allowed_namespaces to be deleted, got %q", err)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "web", "namespace": "team-a"}},
+	})
+	err = r.Create(d, conn)
+	if err != nil || !created {
+		t.Fatalf("Expected allowed namespace to be created, got %v", err)
+	}
+
+	// Other providers aren't restricted
+	other, err := kubernetes.NewForConfig(&restclient.Config{Host: "http://localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := checkNamespaceAllowed(other, "team-b"); err != nil {
+		t.Fatalf("Expected unrestricted provider to allow any namespace, got %q", err)
+	}
+}
+
+func TestResourceNamespaceFunc(t *testing.T) {
+	p := Provider().(*schema.Provider)
+	cases := []struct {
+		Resource string
+		Config   map[string]interface{}
+		Expected string
+	}{
+		{
+			"kubernetes_config_map",
+			map[string]interface{}{"metadata": []interface{}{map[string]interface{}{"name": "cfg"}}},
+			"default",
+		},
+		{
+			"kubernetes_namespace",
+			map[string]interface{}{"metadata": []interface{}{map[string]interface{}{"name": "team-a"}}},
+			"team-a",
+		},
+		{
+			"kubernetes_eviction",
+			map[string]interface{}{"namespace": "team-a", "pod_name": "web"},
+			"team-a",
+		},
+		{
+			"kubernetes_labels",
+			map[string]interface{}{"kind": "Pod", "name": "web", "labels": map[string]interface{}{"a": "b"}},
+			"default",
+		},
+		{
+			"kubernetes_labels",
+			map[string]interface{}{"kind": "Namespace", "name": "team-a", "labels": map[string]interface{}{"a": "b"}},
+			"team-a",
+		},
+		{
+			"kubernetes_labels",
+			map[string]interface{}{"kind": "Node", "name": "node-1", "labels": map[string]interface{}{"a": "b"}},
+			"",
+		},
+	}
+	for _, tc := range cases {
+		r := p.ResourcesMap[tc.Resource]
+		f := resourceNamespaceFunc(tc.Resource, r)
+		if f == nil {
+			t.Fatalf("%s: expected a namespace func", tc.Resource)
+		}
+		d := schema.TestResourceDataRaw(t, r.Schema, tc.Config)
+		if ns := f(d); ns != tc.Expected {
+			t.Fatalf("%s: expected namespace %q, got %q", tc.Resource, tc.Expected, ns)
+		}
+	}
+
+	for _, name := range []string{"kubernetes_node_drain", "kubernetes_persistent_volume", "kubernetes_storage_class"} {
+		if resourceNamespaceFunc(name, p.ResourcesMap[name]) != nil {
+			t.Fatalf("%s: expected no namespace func for cluster-scoped resource", name)
+		}
+	}
+}
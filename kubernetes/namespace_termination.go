@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// namespaceTerminating tells whether the namespace is being deleted or gone already,
+// in which case the namespace controller takes care of deleting everything in it
+func namespaceTerminating(conn *kubernetes.Clientset, name string) (bool, error) {
+	ns, err := conn.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return true, nil
+		}
+		return false, err
+	}
+	return ns.DeletionTimestamp != nil || ns.Status.Phase == api.NamespaceTerminating, nil
+}
+
+// withNamespaceTerminationSkip makes destroying objects in a namespace which is
+// being deleted (e.g. in the same plan) succeed without deleting them one by one.
+// Objects the namespace controller removed first (404) or which are already
+// being finalized (409) don't fail the destroy either.
+func withNamespaceTerminationSkip(name string, r *schema.Resource) {
+	namespace := resourceNamespaceFunc(name, r)
+	// The namespace resource waits for its own termination
+	if namespace == nil || name == "kubernetes_namespace" || r.Delete == nil {
+		return
+	}
+
+	del := r.Delete
+	r.Delete = func(d *schema.ResourceData, meta interface{}) error {
+		conn, ok := meta.(*kubernetes.Clientset)
+		ns := namespace(d)
+		if !ok || ns == "" {
+			return del(d, meta)
+		}
+
+		terminating, err := namespaceTerminating(conn, ns)
+		if err != nil {
+			log.Printf("[DEBUG] Failed to read namespace %s: %s", ns, err)
+		}
+		if terminating {
+			log.Printf("[INFO] Namespace %s is being deleted, skipping deletion of %s", ns, d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		err = del(d, meta)
+		statusErr, ok := err.(*errors.StatusError)
+		if !ok || (statusErr.ErrStatus.Code != 404 && statusErr.ErrStatus.Code != 409) {
+			return err
+		}
+		if terminating, _ := namespaceTerminating(conn, ns); !terminating {
+			return err
+		}
+		log.Printf("[INFO] Namespace %s is being deleted, ignoring error deleting %s: %s", ns, d.Id(), err)
+		d.SetId("")
+		return nil
+	}
+}
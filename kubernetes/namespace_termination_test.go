@@ -0,0 +1,82 @@
+package kubernetes
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestWithNamespaceTerminationSkip(t *testing.T) {
+	deletes := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v1/namespaces/active":
+			fmt.Fprint(w, `{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"active"},"status":{"phase":"Active"}}`)
+		case r.Method == "GET" && r.URL.Path == "/api/v1/namespaces/terminating":
+			fmt.Fprint(w, `{"kind":"Namespace","apiVersion":"v1","metadata":{"name":"terminating"},"status":{"phase":"Terminating"}}`)
+		case r.Method == "DELETE":
+			deletes++
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+		}
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("config map", true),
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			namespace, name, err := idParts(d.Id())
+			if err != nil {
+				return err
+			}
+			return meta.(*kubernetes.Clientset).CoreV1().ConfigMaps(namespace).Delete(name, &metav1.DeleteOptions{})
+		},
+	}
+	withNamespaceTerminationSkip("kubernetes_config_map", r)
+
+	newData := func(namespace string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "cfg", "namespace": namespace}},
+		})
+		d.SetId(namespace + "/cfg")
+		return d
+	}
+
+	// Deleting from an active namespace still fails on errors
+	if err := r.Delete(newData("active"), conn); err == nil {
+		t.Fatal("Expected not found error in active namespace")
+	}
+	if deletes != 1 {
+		t.Fatalf("Expected 1 delete request, got %d", deletes)
+	}
+
+	for _, ns := range []string{"terminating", "gone"} {
+		d := newData(ns)
+		if err := r.Delete(d, conn); err != nil {
+			t.Fatalf("%s: expected deletion to be skipped, got %q", ns, err)
+		}
+		if d.Id() != "" {
+			t.Fatalf("%s: expected resource to be removed from state", ns)
+		}
+	}
+	if deletes != 1 {
+		t.Fatalf("Expected no further delete requests, got %d", deletes)
+	}
+}
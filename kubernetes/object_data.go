@@ -0,0 +1,230 @@
+package kubernetes
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// objectDataResource builds a resource managing a set of keys
+// in the data of an existing object of the given kind (e.g. a config map
+// maintained by the cluster installer), leaving other keys untouched.
+func objectDataResource(kind, objectName string) *schema.Resource {
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectDataCreate(kind, d, meta)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectDataRead(kind, d, meta)
+		},
+		Exists: func(d *schema.ResourceData, meta interface{}) (bool, error) {
+			return resourceKubernetesObjectDataExists(kind, d, meta)
+		},
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectDataUpdate(kind, d, meta)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectDataDelete(kind, d, meta)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: fmt.Sprintf("Namespace of the %s.", objectName),
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: fmt.Sprintf("Name of the %s.", objectName),
+				Required:    true,
+				ForceNew:    true,
+			},
+			"data": {
+				Type:        schema.TypeMap,
+				Description: fmt.Sprintf("Keys to set in the data of the %s. Keys which are not listed here are left untouched.", objectName),
+				Required:    true,
+				Sensitive:   isObjectDataEncoded(kind),
+			},
+		},
+	}
+}
+
+func resourceKubernetesObjectDataCreate(kind string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace := d.Get("namespace").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[INFO] Setting data of %s %s/%s", kind, namespace, name)
+	err := patchObjectData(conn, kind, namespace, name, d.Get("data").(map[string]interface{}))
+	if err != nil {
+		return wrapError(err, "Failed to set data of %s %q", kind, name)
+	}
+
+	d.SetId(buildId(metav1.ObjectMeta{Namespace: namespace, Name: name}))
+
+	return resourceKubernetesObjectDataRead(kind, d, meta)
+}
+
+func resourceKubernetesObjectDataRead(kind string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading data of %s %s/%s", kind, namespace, name)
+	current, err := getObjectData(conn, kind, namespace, name)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	// Only report keys managed here, the rest belongs to someone else
+	m := make(map[string]string)
+	for k := range d.Get("data").(map[string]interface{}) {
+		if v, ok := current[k]; ok {
+			m[k] = v
+		}
+	}
+
+	d.Set("namespace", namespace)
+	d.Set("name", name)
+	d.Set("data", m)
+
+	return nil
+}
+
+func resourceKubernetesObjectDataUpdate(kind string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("data") {
+		oldV, newV := d.GetChange("data")
+		m := newV.(map[string]interface{})
+		for k := range oldV.(map[string]interface{}) {
+			if _, ok := m[k]; !ok {
+				m[k] = nil
+			}
+		}
+
+		log.Printf("[INFO] Updating data of %s %s/%s", kind, namespace, name)
+		err = patchObjectData(conn, kind, namespace, name, m)
+		if err != nil {
+			return wrapError(err, "Failed to update data of %s %q", kind, name)
+		}
+	}
+
+	return resourceKubernetesObjectDataRead(kind, d, meta)
+}
+
+func resourceKubernetesObjectDataDelete(kind string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]interface{})
+	for k := range d.Get("data").(map[string]interface{}) {
+		m[k] = nil
+	}
+
+	log.Printf("[INFO] Removing data from %s %s/%s", kind, namespace, name)
+	err = patchObjectData(conn, kind, namespace, name, m)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesObjectDataExists(kind string, d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking %s %s/%s", kind, namespace, name)
+	_, err = getObjectMeta(conn, kind, namespace, name)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func getObjectData(conn *kubernetes.Clientset, kind, namespace, name string) (map[string]string, error) {
+	raw, err := getObject(conn, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj struct {
+		Data map[string]string `json:"data"`
+	}
+	err = json.Unmarshal(raw, &obj)
+	if err != nil {
+		return nil, err
+	}
+	if isObjectDataEncoded(kind) {
+		for k, v := range obj.Data {
+			decoded, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to decode %q of %s %q: %s", k, kind, name, err)
+			}
+			obj.Data[k] = string(decoded)
+		}
+	}
+	return obj.Data, nil
+}
+
+// isObjectDataEncoded tells whether values of the data map
+// of the kind are base64 encoded by the API
+func isObjectDataEncoded(kind string) bool {
+	return kind == "Secret"
+}
+
+// patchObjectData sets values of the given keys in the data
+// of an object, keys with nil values are removed
+func patchObjectData(conn *kubernetes.Clientset, kind, namespace, name string, values map[string]interface{}) error {
+	if isObjectDataEncoded(kind) {
+		encoded := make(map[string]interface{}, len(values))
+		for k, v := range values {
+			if v != nil {
+				v = base64.StdEncoding.EncodeToString([]byte(v.(string)))
+			}
+			encoded[k] = v
+		}
+		values = encoded
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"data": values,
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Patching data of %s %q", kind, name)
+	return patchObject(conn, kind, namespace, name, data)
+}
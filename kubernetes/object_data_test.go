@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestObjectData_secretEncoding(t *testing.T) {
+	var patch map[string]map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &patch)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// "cGFzc3dvcmQ=" is "password"
+		fmt.Fprint(w, `{"kind":"Secret","apiVersion":"v1","metadata":{"name":"creds","namespace":"default"},"data":{"password":"cGFzc3dvcmQ="}}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := getObjectData(conn, "Secret", "default", "creds")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(data, map[string]string{"password": "password"}) {
+		t.Fatalf("Expected decoded secret data, got %#v", data)
+	}
+
+	err = patchObjectData(conn, "Secret", "default", "creds", map[string]interface{}{
+		"username": "admin",
+		"password": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"username": "YWRtaW4=",
+		"password": nil,
+	}
+	if !reflect.DeepEqual(patch["data"], expected) {
+		t.Fatalf("Expected encoded patch %#v, got %#v", expected, patch["data"])
+	}
+}
@@ -0,0 +1,438 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// objectKind describes how to reach objects of a given kind
+// for resources which only manage a part of arbitrary objects
+type objectKind struct {
+	// groupVersions lists API versions serving the kind, newest first.
+	// The vendored clients only know versions which newer servers dropped.
+	groupVersions []string
+	resource      string
+	namespaced    bool
+}
+
+var (
+	appsGroupVersions        = []string{"apps/v1", "extensions/v1beta1"}
+	ingressGroupVersions     = []string{"networking.k8s.io/v1", "networking.k8s.io/v1beta1", "extensions/v1beta1"}
+	statefulSetGroupVersions = []string{"apps/v1", "apps/v1beta1"}
+)
+
+var objectKinds = map[string]objectKind{
+	"ConfigMap":             {[]string{"v1"}, "configmaps", true},
+	"DaemonSet":             {appsGroupVersions, "daemonsets", true},
+	"Deployment":            {appsGroupVersions, "deployments", true},
+	"Ingress":               {ingressGroupVersions, "ingresses", true},
+	"Job":                   {[]string{"batch/v1"}, "jobs", true},
+	"Namespace":             {[]string{"v1"}, "namespaces", false},
+	"Node":                  {[]string{"v1"}, "nodes", false},
+	"PersistentVolume":      {[]string{"v1"}, "persistentvolumes", false},
+	"PersistentVolumeClaim": {[]string{"v1"}, "persistentvolumeclaims", true},
+	"Pod":                   {[]string{"v1"}, "pods", true},
+	"ReplicaSet":            {appsGroupVersions, "replicasets", true},
+	"ReplicationController": {[]string{"v1"}, "replicationcontrollers", true},
+	"Secret":                {[]string{"v1"}, "secrets", true},
+	"Service":               {[]string{"v1"}, "services", true},
+	"ServiceAccount":        {[]string{"v1"}, "serviceaccounts", true},
+	"StatefulSet":           {statefulSetGroupVersions, "statefulsets", true},
+	"StorageClass":          {[]string{"storage.k8s.io/v1"}, "storageclasses", false},
+}
+
+func supportedObjectKinds() []string {
+	kinds := make([]string, 0, len(objectKinds))
+	for k := range objectKinds {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+func buildObjectId(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func objectIdParts(id string) (string, string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 {
+		err := fmt.Errorf("Unexpected ID format (%q), expected %q.", id, "kind/namespace/name")
+		return "", "", "", err
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func lookupObjectKind(kind string) (objectKind, error) {
+	k, ok := objectKinds[kind]
+	if !ok {
+		return k, fmt.Errorf("Unsupported kind %q, expected one of %q", kind, supportedObjectKinds())
+	}
+	return k, nil
+}
+
+// request points r at the object in the given API version,
+// or at the objects of the kind when name is empty
+func (k objectKind) request(r *rest.Request, gv, namespace, name string) *rest.Request {
+	if strings.Contains(gv, "/") {
+		r = r.AbsPath("/apis", gv)
+	} else {
+		r = r.AbsPath("/api", gv)
+	}
+	if k.namespaced && namespace != "" {
+		r = r.Namespace(namespace)
+	}
+	r = r.Resource(k.resource)
+	if name != "" {
+		r = r.Name(name)
+	}
+	return r
+}
+
+// try calls f with the API versions serving the kind, newest first,
+// until it returns something else than a not found error
+func (k objectKind) try(f func(gv string) error) error {
+	var err error
+	for _, gv := range k.groupVersions {
+		err = f(gv)
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			log.Printf("[DEBUG] %s not found in %s", k.resource, gv)
+			continue
+		}
+		return err
+	}
+	return err
+}
+
+// create creates the object in the first API version serving the kind and
+// decodes the created object into out. The server fills in the API version
+// and kind of the request path.
+func (k objectKind) create(conn *kubernetes.Clientset, namespace string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	var raw []byte
+	err = k.try(func(gv string) error {
+		raw, err = k.request(conn.CoreV1().RESTClient().Post(), gv, namespace, "").Body(body).Do().Raw()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(raw, out)
+	if err != nil {
+		return fmt.Errorf("Failed to decode %s: %s", k.resource, err)
+	}
+	return nil
+}
+
+// get fetches the object from the first API version serving the kind
+// and decodes it into out
+func (k objectKind) get(conn *kubernetes.Clientset, namespace, name string, out interface{}) error {
+	var raw []byte
+	err := k.try(func(gv string) error {
+		var err error
+		raw, err = k.request(conn.CoreV1().RESTClient().Get(), gv, namespace, name).Do().Raw()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(raw, out)
+	if err != nil {
+		return fmt.Errorf("Failed to decode %s %s/%s: %s", k.resource, namespace, name, err)
+	}
+	return nil
+}
+
+// patch patches the object in the first API version serving the kind
+// and decodes the patched object into out
+func (k objectKind) patch(conn *kubernetes.Clientset, namespace, name string, pt pkgApi.PatchType, data []byte, out interface{}) error {
+	var raw []byte
+	err := k.try(func(gv string) error {
+		var err error
+		raw, err = k.request(conn.CoreV1().RESTClient().Patch(pt), gv, namespace, name).Body(data).Do().Raw()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(raw, out)
+	if err != nil {
+		return fmt.Errorf("Failed to decode %s %s/%s: %s", k.resource, namespace, name, err)
+	}
+	return nil
+}
+
+// delete deletes the object from the first API version serving the kind
+func (k objectKind) delete(conn *kubernetes.Clientset, namespace, name string) error {
+	return k.try(func(gv string) error {
+		return k.request(conn.CoreV1().RESTClient().Delete(), gv, namespace, name).Do().Error()
+	})
+}
+
+// getObject fetches the raw object from the first API version serving it
+func getObject(conn *kubernetes.Clientset, kind, namespace, name string) ([]byte, error) {
+	k, err := lookupObjectKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	var raw []byte
+	err = k.try(func(gv string) error {
+		raw, err = k.request(conn.CoreV1().RESTClient().Get(), gv, namespace, name).Do().Raw()
+		return err
+	})
+	return raw, err
+}
+
+// patchObject merge patches the object in the first API version serving it
+func patchObject(conn *kubernetes.Clientset, kind, namespace, name string, data []byte) error {
+	k, err := lookupObjectKind(kind)
+	if err != nil {
+		return err
+	}
+	return k.try(func(gv string) error {
+		return k.request(conn.CoreV1().RESTClient().Patch(pkgApi.MergePatchType), gv, namespace, name).Body(data).Do().Error()
+	})
+}
+
+func getObjectMeta(conn *kubernetes.Clientset, kind, namespace, name string) (*metav1.ObjectMeta, error) {
+	raw, err := getObject(conn, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}
+	err = json.Unmarshal(raw, &obj)
+	if err != nil {
+		return nil, err
+	}
+	return &obj.Metadata, nil
+}
+
+// objectStatus holds the commonly used parts of the status of an object,
+// replica counts are only set for workloads
+type objectStatus struct {
+	Phase         string
+	Conditions    map[string]string
+	Replicas      int32
+	ReadyReplicas int32
+}
+
+func getObjectStatus(conn *kubernetes.Clientset, kind, namespace, name string) (*objectStatus, error) {
+	raw, err := getObject(conn, kind, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj struct {
+		Status struct {
+			Phase      string `json:"phase"`
+			Conditions []struct {
+				Type   string `json:"type"`
+				Status string `json:"status"`
+			} `json:"conditions"`
+			Replicas      int32 `json:"replicas"`
+			ReadyReplicas int32 `json:"readyReplicas"`
+			// Daemon sets count scheduled pods instead of replicas
+			DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+			NumberReady            int32 `json:"numberReady"`
+		} `json:"status"`
+	}
+	err = json.Unmarshal(raw, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &objectStatus{
+		Phase:         obj.Status.Phase,
+		Conditions:    make(map[string]string, len(obj.Status.Conditions)),
+		Replicas:      obj.Status.Replicas,
+		ReadyReplicas: obj.Status.ReadyReplicas,
+	}
+	for _, c := range obj.Status.Conditions {
+		out.Conditions[c.Type] = c.Status
+	}
+	if kind == "DaemonSet" {
+		out.Replicas = obj.Status.DesiredNumberScheduled
+		out.ReadyReplicas = obj.Status.NumberReady
+	}
+	return out, nil
+}
+
+// patchObjectMetaMap sets values of the given keys in a metadata map
+// (labels or annotations) of an object, keys with nil values are removed
+func patchObjectMetaMap(conn *kubernetes.Clientset, kind, namespace, name, field string, values map[string]interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			field: values,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Patching %s of %s %q: %v", field, kind, name, string(data))
+	return patchObject(conn, kind, namespace, name, data)
+}
+
+// objectListPageSize is the number of objects requested per List call,
+// servers which don't support chunking return everything at once
+const objectListPageSize = 500
+
+// listObjectMetas lists metadata of objects of a kind across all namespaces
+// when namespace is empty, fetching at most max objects (0 means no limit)
+func listObjectMetas(conn *kubernetes.Clientset, kind, namespace, labelSelector string, max int) ([]metav1.ObjectMeta, error) {
+	k, err := lookupObjectKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	metas := make([]metav1.ObjectMeta, 0)
+	continueToken := ""
+	// The API version is picked with the first page and kept for the others
+	gv := ""
+	for {
+		pageSize := objectListPageSize
+		if max > 0 && max-len(metas) < pageSize {
+			pageSize = max - len(metas)
+		}
+		listPage := func(gv string) ([]byte, error) {
+			r := k.request(conn.CoreV1().RESTClient().Get(), gv, namespace, "")
+			if labelSelector != "" {
+				r = r.Param("labelSelector", labelSelector)
+			}
+			r = r.Param("limit", strconv.Itoa(pageSize))
+			if continueToken != "" {
+				r = r.Param("continue", continueToken)
+			}
+			return r.Do().Raw()
+		}
+
+		var raw []byte
+		if gv == "" {
+			err = k.try(func(v string) error {
+				raw, err = listPage(v)
+				gv = v
+				return err
+			})
+		} else {
+			raw, err = listPage(gv)
+		}
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Metadata struct {
+				Continue string `json:"continue"`
+			} `json:"metadata"`
+			Items []struct {
+				Metadata metav1.ObjectMeta `json:"metadata"`
+			} `json:"items"`
+		}
+		err = json.Unmarshal(raw, &list)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("[DEBUG] Received %d %s objects", len(list.Items), kind)
+
+		for _, item := range list.Items {
+			metas = append(metas, item.Metadata)
+		}
+		if max > 0 && len(metas) >= max {
+			return metas[:max], nil
+		}
+		if list.Metadata.Continue == "" {
+			return metas, nil
+		}
+		continueToken = list.Metadata.Continue
+	}
+}
+
+// scalableObjectKinds are kinds exposing the scale subresource
+var scalableObjectKinds = []string{"Deployment", "ReplicaSet", "ReplicationController", "StatefulSet"}
+
+// objectScale holds the parts of a Scale object we care about,
+// the raw object is kept to send it back unchanged otherwise
+type objectScale struct {
+	Replicas        int32
+	CurrentReplicas int32
+	raw             map[string]interface{}
+	// gv is the API version the scale was read from, which it's sent back to
+	gv string
+}
+
+func getObjectScale(conn *kubernetes.Clientset, kind, namespace, name string) (*objectScale, error) {
+	k, err := lookupObjectKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	var raw []byte
+	var gv string
+	err = k.try(func(v string) error {
+		raw, err = k.request(conn.CoreV1().RESTClient().Get(), v, namespace, name).SubResource("scale").Do().Raw()
+		gv = v
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var scale struct {
+		Spec struct {
+			Replicas int32 `json:"replicas"`
+		} `json:"spec"`
+		Status struct {
+			Replicas int32 `json:"replicas"`
+		} `json:"status"`
+	}
+	err = json.Unmarshal(raw, &scale)
+	if err != nil {
+		return nil, err
+	}
+	out := &objectScale{
+		Replicas:        scale.Spec.Replicas,
+		CurrentReplicas: scale.Status.Replicas,
+		gv:              gv,
+	}
+	err = json.Unmarshal(raw, &out.raw)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// updateObjectScale sets desired replicas of an object via its scale subresource,
+// the resource version of the scale guards against concurrent changes
+func updateObjectScale(conn *kubernetes.Clientset, kind, namespace, name string, scale *objectScale) error {
+	k, err := lookupObjectKind(kind)
+	if err != nil {
+		return err
+	}
+	spec, ok := scale.raw["spec"].(map[string]interface{})
+	if !ok {
+		spec = make(map[string]interface{})
+		scale.raw["spec"] = spec
+	}
+	spec["replicas"] = scale.Replicas
+
+	data, err := json.Marshal(scale.raw)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Updating scale of %s %q: %v", kind, name, string(data))
+	return k.request(conn.CoreV1().RESTClient().Put(), scale.gv, namespace, name).SubResource("scale").Body(data).Do().Error()
+}
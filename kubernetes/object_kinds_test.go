@@ -0,0 +1,157 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestListObjectMetas_chunked(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		// Serve 5 config maps in pages of (at most) 2
+		start := 0
+		fmt.Sscanf(r.URL.Query().Get("continue"), "%d", &start)
+		end := start + 2
+		cont := fmt.Sprintf("%d", end)
+		if end >= 5 {
+			end = 5
+			cont = ""
+		}
+		items := ""
+		for i := start; i < end; i++ {
+			if items != "" {
+				items += ","
+			}
+			items += fmt.Sprintf(`{"metadata":{"name":"cm-%d","namespace":"default"}}`, i)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"kind":"ConfigMapList","apiVersion":"v1","metadata":{"continue":%q},"items":[%s]}`, cont, items)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metas, err := listObjectMetas(conn, "ConfigMap", "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metas) != 5 || metas[4].Name != "cm-4" {
+		t.Fatalf("Expected all 5 config maps, got %#v", metas)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 chunked requests, got %q", requests)
+	}
+
+	requests = nil
+	metas, err = listObjectMetas(conn, "ConfigMap", "default", "app=web", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metas) != 3 || metas[2].Name != "cm-2" {
+		t.Fatalf("Expected first 3 config maps, got %#v", metas)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("Expected listing to stop after 2 requests, got %q", requests)
+	}
+}
+
+func TestListObjectMetas_appsV1(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/apis/apps/v1/deployments" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+			return
+		}
+		if r.URL.Query().Get("continue") == "" {
+			fmt.Fprint(w, `{"kind":"DeploymentList","apiVersion":"apps/v1","metadata":{"continue":"1"},"items":[{"metadata":{"name":"web","namespace":"default"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"kind":"DeploymentList","apiVersion":"apps/v1","metadata":{},"items":[{"metadata":{"name":"api","namespace":"default"}}]}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metas, err := listObjectMetas(conn, "Deployment", "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metas) != 2 || metas[1].Name != "api" {
+		t.Fatalf("Expected both deployments, got %#v", metas)
+	}
+	expected := []string{"/apis/apps/v1/deployments", "/apis/apps/v1/deployments"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("Expected the newest API version to be used for every page, got %q", paths)
+	}
+}
+
+func TestObjectScale(t *testing.T) {
+	// Servers since Kubernetes 1.16 only serve apps/v1, older ones extensions/v1beta1
+	for _, gv := range []string{"apps/v1", "extensions/v1beta1"} {
+		testObjectScale(t, gv)
+	}
+}
+
+func testObjectScale(t *testing.T, gv string) {
+	var updated map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/apis/"+gv+"/namespaces/default/deployments/web/scale" {
+			if r.Method == "PUT" {
+				t.Errorf("Expected the scale to be updated in %s, got a request to %s", gv, r.URL.Path)
+			}
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+			return
+		}
+		if r.Method == "PUT" {
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &updated)
+		}
+		fmt.Fprint(w, `{"kind":"Scale","apiVersion":"autoscaling/v1","metadata":{"name":"web","namespace":"default","resourceVersion":"42"},"spec":{"replicas":2},"status":{"replicas":1}}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scale, err := getObjectScale(conn, "Deployment", "default", "web")
+	if err != nil {
+		t.Fatalf("%s: %s", gv, err)
+	}
+	if scale.Replicas != 2 || scale.CurrentReplicas != 1 {
+		t.Fatalf("%s: Unexpected scale: %#v", gv, scale)
+	}
+
+	scale.Replicas = 5
+	err = updateObjectScale(conn, "Deployment", "default", "web", scale)
+	if err != nil {
+		t.Fatalf("%s: %s", gv, err)
+	}
+	if updated["spec"].(map[string]interface{})["replicas"] != float64(5) {
+		t.Fatalf("%s: Expected 5 replicas to be requested, got %#v", gv, updated)
+	}
+	if updated["metadata"].(map[string]interface{})["resourceVersion"] != "42" {
+		t.Fatalf("%s: Expected resource version to be kept, got %#v", gv, updated)
+	}
+}
@@ -0,0 +1,194 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// objectMetaMapResource builds a resource managing a set of keys
+// of a metadata map (labels or annotations) on an existing object
+// of any supported kind, leaving other keys of the map untouched.
+func objectMetaMapResource(field, description string, validate schema.SchemaValidateFunc) *schema.Resource {
+	return &schema.Resource{
+		Create: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectMetaMapCreate(field, d, meta)
+		},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectMetaMapRead(field, d, meta)
+		},
+		Exists: resourceKubernetesObjectMetaMapExists,
+		Update: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectMetaMapUpdate(field, d, meta)
+		},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return resourceKubernetesObjectMetaMapDelete(field, d, meta)
+		},
+
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "Kind of the object, e.g. `Node` or `Namespace`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAttributeValueIsIn(supportedObjectKinds()),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the object. Ignored for cluster-scoped kinds, defaults to `default` for namespaced ones.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the object.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			field: {
+				Type:         schema.TypeMap,
+				Description:  description,
+				Required:     true,
+				ValidateFunc: validate,
+			},
+		},
+	}
+}
+
+func resourceKubernetesObjectMetaMapCreate(field string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind := d.Get("kind").(string)
+	name := d.Get("name").(string)
+	namespace := ""
+	if objectKinds[kind].namespaced {
+		namespace = d.Get("namespace").(string)
+		if namespace == "" {
+			namespace = "default"
+		}
+	}
+
+	log.Printf("[INFO] Setting %s of %s %s/%s", field, kind, namespace, name)
+	err := patchObjectMetaMap(conn, kind, namespace, name, field, d.Get(field).(map[string]interface{}))
+	if err != nil {
+		return wrapError(err, "Failed to set %s of %s %q", field, kind, name)
+	}
+
+	d.SetId(buildObjectId(kind, namespace, name))
+
+	return resourceKubernetesObjectMetaMapRead(field, d, meta)
+}
+
+func resourceKubernetesObjectMetaMapRead(field string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind, namespace, name, err := objectIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading %s of %s %s/%s", field, kind, namespace, name)
+	objMeta, err := getObjectMeta(conn, kind, namespace, name)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	current := objectMetaMap(objMeta, field)
+
+	// Only report keys managed here, the rest belongs to someone else
+	m := make(map[string]string)
+	for k := range d.Get(field).(map[string]interface{}) {
+		if v, ok := current[k]; ok {
+			m[k] = v
+		}
+	}
+
+	d.Set("kind", kind)
+	d.Set("namespace", namespace)
+	d.Set("name", name)
+	d.Set(field, m)
+
+	return nil
+}
+
+func resourceKubernetesObjectMetaMapUpdate(field string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind, namespace, name, err := objectIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange(field) {
+		oldV, newV := d.GetChange(field)
+		m := newV.(map[string]interface{})
+		for k := range oldV.(map[string]interface{}) {
+			if _, ok := m[k]; !ok {
+				m[k] = nil
+			}
+		}
+
+		log.Printf("[INFO] Updating %s of %s %s/%s", field, kind, namespace, name)
+		err = patchObjectMetaMap(conn, kind, namespace, name, field, m)
+		if err != nil {
+			return wrapError(err, "Failed to update %s of %s %q", field, kind, name)
+		}
+	}
+
+	return resourceKubernetesObjectMetaMapRead(field, d, meta)
+}
+
+func resourceKubernetesObjectMetaMapDelete(field string, d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind, namespace, name, err := objectIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	m := make(map[string]interface{})
+	for k := range d.Get(field).(map[string]interface{}) {
+		m[k] = nil
+	}
+
+	log.Printf("[INFO] Removing %s from %s %s/%s", field, kind, namespace, name)
+	err = patchObjectMetaMap(conn, kind, namespace, name, field, m)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesObjectMetaMapExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind, namespace, name, err := objectIdParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking %s %s/%s", kind, namespace, name)
+	_, err = getObjectMeta(conn, kind, namespace, name)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func objectMetaMap(objMeta *metav1.ObjectMeta, field string) map[string]string {
+	if field == "annotations" {
+		return objMeta.Annotations
+	}
+	return objMeta.Labels
+}
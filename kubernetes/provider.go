@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/mitchellh/go-homedir"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -17,7 +18,7 @@ import (
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"host": {
 				Type:        schema.TypeString,
@@ -101,31 +102,88 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_LOAD_CONFIG_FILE", true),
 				Description: "Load local kubeconfig.",
 			},
+			"node_port_range": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("KUBE_NODE_PORT_RANGE", "30000-32767"),
+				Description:  "Range of ports the cluster allocates node ports from (`--service-node-port-range` of the API server).",
+				ValidateFunc: validatePortRange,
+			},
+			"allowed_namespaces": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Namespaces resources of this provider may manage objects in. Any namespace is allowed when not set.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"skip_unreachable_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBE_SKIP_UNREACHABLE_ON_DESTROY", false),
+				Description: "Consider resources destroyed when the cluster can't be reached, e.g. because it has been destroyed already.",
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"kubernetes_service":       dataSourceKubernetesService(),
-			"kubernetes_storage_class": dataSourceKubernetesStorageClass(),
+			"kubernetes_cluster_role":               dataSourceKubernetesClusterRole(),
+			"kubernetes_cluster_role_binding":       dataSourceKubernetesClusterRoleBinding(),
+			"kubernetes_cron_job":                   dataSourceKubernetesCronJob(),
+			"kubernetes_custom_resource_definition": dataSourceKubernetesCustomResourceDefinition(),
+			"kubernetes_namespace":                  dataSourceKubernetesNamespace(),
+			"kubernetes_object_status":              dataSourceKubernetesObjectStatus(),
+			"kubernetes_objects":                    dataSourceKubernetesObjects(),
+			"kubernetes_role":                       dataSourceKubernetesRole(),
+			"kubernetes_role_binding":               dataSourceKubernetesRoleBinding(),
+			"kubernetes_role_bindings":              dataSourceKubernetesRoleBindings(),
+			"kubernetes_secret":                     dataSourceKubernetesSecret(),
+			"kubernetes_service":                    dataSourceKubernetesService(),
+			"kubernetes_storage_class":              dataSourceKubernetesStorageClass(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"kubernetes_annotations":               resourceKubernetesAnnotations(),
 			"kubernetes_config_map":                resourceKubernetesConfigMap(),
+			"kubernetes_config_map_data":           resourceKubernetesConfigMapData(),
+			"kubernetes_cron_job_trigger":          resourceKubernetesCronJobTrigger(),
+			"kubernetes_default_service_account":   resourceKubernetesDefaultServiceAccount(),
+			"kubernetes_eviction":                  resourceKubernetesEviction(),
 			"kubernetes_horizontal_pod_autoscaler": resourceKubernetesHorizontalPodAutoscaler(),
 			"kubernetes_job":                       resourceKubernetesJob(),
+			"kubernetes_labels":                    resourceKubernetesLabels(),
 			"kubernetes_limit_range":               resourceKubernetesLimitRange(),
 			"kubernetes_namespace":                 resourceKubernetesNamespace(),
+			"kubernetes_namespace_policy":          resourceKubernetesNamespacePolicy(),
+			"kubernetes_node":                      resourceKubernetesNode(),
+			"kubernetes_node_drain":                resourceKubernetesNodeDrain(),
+			"kubernetes_node_taint":                resourceKubernetesNodeTaint(),
 			"kubernetes_persistent_volume":         resourceKubernetesPersistentVolume(),
 			"kubernetes_persistent_volume_claim":   resourceKubernetesPersistentVolumeClaim(),
 			"kubernetes_pod":                       resourceKubernetesPod(),
+			"kubernetes_pod_template":              resourceKubernetesPodTemplate(),
+			"kubernetes_replica_set":               resourceKubernetesReplicaSet(),
 			"kubernetes_replication_controller":    resourceKubernetesReplicationController(),
 			"kubernetes_resource_quota":            resourceKubernetesResourceQuota(),
+			"kubernetes_scale":                     resourceKubernetesScale(),
 			"kubernetes_secret":                    resourceKubernetesSecret(),
+			"kubernetes_secret_data":               resourceKubernetesSecretData(),
 			"kubernetes_service":                   resourceKubernetesService(),
 			"kubernetes_service_account":           resourceKubernetesServiceAccount(),
+			"kubernetes_service_account_token":     resourceKubernetesServiceAccountToken(),
 			"kubernetes_storage_class":             resourceKubernetesStorageClass(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
+
+	for _, r := range p.DataSourcesMap {
+		withErrorCodes(r)
+	}
+	for name, r := range p.ResourcesMap {
+		withNamespaceGuard(name, r)
+		withNamespaceTerminationSkip(name, r)
+		withUnreachableSkip(r)
+		withErrorCodes(r)
+	}
+
+	return p
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
@@ -172,10 +230,20 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		cfg.BearerToken = v.(string)
 	}
 
+	pr, err := utilnet.ParsePortRange(d.Get("node_port_range").(string))
+	if err != nil {
+		return nil, err
+	}
+
 	k, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to configure: %s", err)
 	}
+	setProviderOptions(k, providerOptions{
+		allowedNamespaces:        expandStringSlice(d.Get("allowed_namespaces").([]interface{})),
+		skipUnreachableOnDestroy: d.Get("skip_unreachable_on_destroy").(bool),
+		nodePortRange:            *pr,
+	})
 
 	return k, nil
 }
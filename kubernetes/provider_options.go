@@ -0,0 +1,49 @@
+package kubernetes
+
+import (
+	"sync"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// providerOptions are settings of the provider which change the behaviour
+// of resources rather than how the client talks to the cluster
+type providerOptions struct {
+	allowedNamespaces        []string
+	skipUnreachableOnDestroy bool
+	nodePortRange            utilnet.PortRange
+}
+
+// serviceNodePortRange is the range the API server allocates node ports from,
+// the default one of the API server unless configured otherwise
+func (o providerOptions) serviceNodePortRange() utilnet.PortRange {
+	if o.nodePortRange.Size == 0 {
+		return defaultNodePortRange
+	}
+	return o.nodePortRange
+}
+
+// configuredOptions holds the options of each configured provider,
+// keyed by its client, so that aliases can be configured differently
+var configuredOptions = struct {
+	sync.RWMutex
+	m map[*kubernetes.Clientset]providerOptions
+}{m: make(map[*kubernetes.Clientset]providerOptions)}
+
+func setProviderOptions(conn *kubernetes.Clientset, opts providerOptions) {
+	configuredOptions.Lock()
+	defer configuredOptions.Unlock()
+	configuredOptions.m[conn] = opts
+}
+
+func getProviderOptions(meta interface{}) providerOptions {
+	conn, ok := meta.(*kubernetes.Clientset)
+	if !ok {
+		return providerOptions{}
+	}
+
+	configuredOptions.RLock()
+	defer configuredOptions.RUnlock()
+	return configuredOptions.m[conn]
+}
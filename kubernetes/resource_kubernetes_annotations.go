@@ -0,0 +1,11 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesAnnotations() *schema.Resource {
+	return objectMetaMapResource("annotations",
+		"Annotations to set on the object. Annotations which are not listed here are left untouched. More info: http://kubernetes.io/docs/user-guide/annotations",
+		validateAnnotations)
+}
@@ -0,0 +1,67 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesAnnotations_basic(t *testing.T) {
+	key := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_annotations.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesObjectMetaMapDestroy("kubernetes_annotations", "annotations"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesAnnotationsConfig_basic(key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "kind", "ServiceAccount"),
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "name", "default"),
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "annotations.%", "2"),
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "annotations."+key+"-one", "one"),
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "annotations."+key+"-two", "two"),
+					testAccCheckKubernetesObjectMetaMap("ServiceAccount", "default", "default", "annotations", map[string]string{key + "-one": "one", key + "-two": "two"}),
+				),
+			},
+			{
+				Config: testAccKubernetesAnnotationsConfig_modified(key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_annotations.test", "annotations."+key+"-one", "changed"),
+					testAccCheckKubernetesObjectMetaMap("ServiceAccount", "default", "default", "annotations", map[string]string{key + "-one": "changed"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesAnnotationsConfig_basic(key string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_annotations" "test" {
+  kind = "ServiceAccount"
+  name = "default"
+  annotations {
+    "%s-one" = "one"
+    "%s-two" = "two"
+  }
+}
+`, key, key)
+}
+
+func testAccKubernetesAnnotationsConfig_modified(key string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_annotations" "test" {
+  kind = "ServiceAccount"
+  name = "default"
+  annotations {
+    "%s-one" = "changed"
+  }
+}
+`, key)
+}
@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -30,6 +31,19 @@ func resourceKubernetesConfigMap() *schema.Resource {
 				Description: "A map of the configuration data.",
 				Optional:    true,
 			},
+			"binary_data": {
+				Type:         schema.TypeMap,
+				Description:  "A map of base64 encoded binary configuration data, for values which aren't valid UTF-8. Requires Kubernetes 1.10+.",
+				Optional:     true,
+				ValidateFunc: validateBase64EncodedMap,
+			},
+			"ignore_keys": {
+				Type:        schema.TypeSet,
+				Description: "Keys of `data` and `binary_data` which are managed outside of Terraform, e.g. written by controllers. They're left untouched and not read into state.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
 		},
 	}
 }
@@ -37,13 +51,20 @@ func resourceKubernetesConfigMap() *schema.Resource {
 func resourceKubernetesConfigMapCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetes.Clientset)
 
+	ignored := sliceOfString(d.Get("ignore_keys").(*schema.Set).List())
+	err := checkIgnoredKeys(ignored, d.Get("data").(map[string]interface{}), d.Get("binary_data").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	cfgMap := api.ConfigMap{
 		ObjectMeta: metadata,
 		Data:       expandStringMap(d.Get("data").(map[string]interface{})),
 	}
+	binaryData := base64DecodeStringMap(d.Get("binary_data").(map[string]interface{}))
 	log.Printf("[INFO] Creating new config map: %#v", cfgMap)
-	out, err := conn.CoreV1().ConfigMaps(metadata.Namespace).Create(&cfgMap)
+	out, err := createConfigMap(conn, &cfgMap, binaryData)
 	if err != nil {
 		return err
 	}
@@ -61,17 +82,22 @@ func resourceKubernetesConfigMapRead(d *schema.ResourceData, meta interface{}) e
 		return err
 	}
 	log.Printf("[INFO] Reading config map %s", name)
-	cfgMap, err := conn.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+	cfgMap, err := getConfigMap(conn, namespace, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
-	log.Printf("[INFO] Received config map: %#v", cfgMap)
+	log.Printf("[INFO] Received config map: %#v", cfgMap.ConfigMap)
 	err = d.Set("metadata", flattenMetadata(cfgMap.ObjectMeta))
 	if err != nil {
 		return err
 	}
+	for _, k := range sliceOfString(d.Get("ignore_keys").(*schema.Set).List()) {
+		delete(cfgMap.Data, k)
+		delete(cfgMap.BinaryData, k)
+	}
 	d.Set("data", cfgMap.Data)
+	d.Set("binary_data", base64EncodeByteMap(cfgMap.BinaryData))
 
 	return nil
 }
@@ -84,12 +110,42 @@ func resourceKubernetesConfigMapUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	// Keys ignored since the last refresh (e.g. right after import)
+	// are still in the old state, they mustn't be removed
+	ignored := sliceOfString(d.Get("ignore_keys").(*schema.Set).List())
+	err = checkIgnoredKeys(ignored, d.Get("data").(map[string]interface{}), d.Get("binary_data").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 	if d.HasChange("data") {
 		oldV, newV := d.GetChange("data")
-		diffOps := diffStringMap("/data/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
+		diffOps := diffStringMap("/data/", withoutKeys(oldV.(map[string]interface{}), ignored), newV.(map[string]interface{}))
 		ops = append(ops, diffOps...)
 	}
+	if d.HasChange("binary_data") {
+		oldV, newV := d.GetChange("binary_data")
+		oldBinary := withoutKeys(oldV.(map[string]interface{}), ignored)
+		if len(oldV.(map[string]interface{})) == 0 {
+			// binaryData is omitted while empty, keys can't be added to it
+			value := newV.(map[string]interface{})
+			if len(ignored) > 0 {
+				// The whole map is replaced, keep ignored keys written since
+				value, err = withIgnoredBinaryData(conn, namespace, name, value, ignored)
+				if err != nil {
+					return err
+				}
+			}
+			ops = append(ops, &AddOperation{
+				Path:  "/binaryData",
+				Value: value,
+			})
+		} else {
+			diffOps := diffStringMap("/binaryData/", oldBinary, newV.(map[string]interface{}))
+			ops = append(ops, diffOps...)
+		}
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("Failed to marshal update operations: %s", err)
@@ -97,7 +153,7 @@ func resourceKubernetesConfigMapUpdate(d *schema.ResourceData, meta interface{})
 	log.Printf("[INFO] Updating config map %q: %v", name, string(data))
 	out, err := conn.CoreV1().ConfigMaps(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update Config Map: %s", err)
+		return wrapError(err, "Failed to update Config Map")
 	}
 	log.Printf("[INFO] Submitted updated config map: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -142,3 +198,72 @@ func resourceKubernetesConfigMapExists(d *schema.ResourceData, meta interface{})
 	}
 	return true, err
 }
+
+// configMapWithBinaryData is a config map including binaryData,
+// which the vendored API types predate
+type configMapWithBinaryData struct {
+	api.ConfigMap `json:",inline"`
+	BinaryData    map[string][]byte `json:"binaryData,omitempty"`
+}
+
+func createConfigMap(conn *kubernetes.Clientset, cfgMap *api.ConfigMap, binaryData map[string][]byte) (*configMapWithBinaryData, error) {
+	in := configMapWithBinaryData{ConfigMap: *cfgMap, BinaryData: binaryData}
+	in.APIVersion = "v1"
+	in.Kind = "ConfigMap"
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := conn.CoreV1().RESTClient().Post().
+		Namespace(cfgMap.Namespace).
+		Resource("configmaps").
+		Body(body).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var out configMapWithBinaryData
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode config map: %s", err)
+	}
+	return &out, nil
+}
+
+// withIgnoredBinaryData adds the current values of ignored binary keys to the map
+func withIgnoredBinaryData(conn *kubernetes.Clientset, namespace, name string, m map[string]interface{}, ignored []string) (map[string]interface{}, error) {
+	cfgMap, err := getConfigMap(conn, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	result := withoutKeys(m, nil)
+	current := base64EncodeByteMap(cfgMap.BinaryData)
+	for _, k := range ignored {
+		if v, ok := current[k]; ok {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+func getConfigMap(conn *kubernetes.Clientset, namespace, name string) (*configMapWithBinaryData, error) {
+	raw, err := conn.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("configmaps").
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var out configMapWithBinaryData
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode config map: %s", err)
+	}
+	return &out, nil
+}
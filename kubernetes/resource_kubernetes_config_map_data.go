@@ -0,0 +1,9 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesConfigMapData() *schema.Resource {
+	return objectDataResource("ConfigMap", "config map")
+}
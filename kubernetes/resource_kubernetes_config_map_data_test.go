@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesConfigMapData_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_config_map_data.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesConfigMapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapDataConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_config_map_data.test", "namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_config_map_data.test", "name", name),
+					resource.TestCheckResourceAttr("kubernetes_config_map_data.test", "data.%", "2"),
+					resource.TestCheckResourceAttr("kubernetes_config_map_data.test", "data.one", "first"),
+					resource.TestCheckResourceAttr("kubernetes_config_map_data.test", "data.two", "second"),
+					testAccCheckKubernetesObjectData("ConfigMap", "default", name, map[string]string{
+						"existing": "untouched",
+						"one":      "first",
+						"two":      "second",
+					}),
+				),
+			},
+			{
+				Config: testAccKubernetesConfigMapDataConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_config_map_data.test", "data.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_config_map_data.test", "data.one", "changed"),
+					testAccCheckKubernetesObjectData("ConfigMap", "default", name, map[string]string{
+						"existing": "untouched",
+						"one":      "changed",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckKubernetesObjectData checks the whole data of the object
+// to make sure keys not managed by the resource are left alone
+func testAccCheckKubernetesObjectData(kind, namespace, name string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		data, err := getObjectData(conn, kind, namespace, name)
+		if err != nil {
+			return err
+		}
+		if len(data) != len(expected) {
+			return fmt.Errorf("%s %q: expected %d keys, got %#v", kind, name, len(expected), data)
+		}
+		for k, v := range expected {
+			if data[k] != v {
+				return fmt.Errorf("%s %q: expected data %q to be %q, got %q", kind, name, k, v, data[k])
+			}
+		}
+		return nil
+	}
+}
+
+func testAccKubernetesConfigMapDataConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+	metadata {
+		name = "%s"
+	}
+	data {
+		existing = "untouched"
+	}
+
+	lifecycle {
+		ignore_changes = ["data"]
+	}
+}
+
+resource "kubernetes_config_map_data" "test" {
+	name = "${kubernetes_config_map.test.metadata.0.name}"
+	data {
+		one = "first"
+		two = "second"
+	}
+}
+`, name)
+}
+
+func testAccKubernetesConfigMapDataConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+	metadata {
+		name = "%s"
+	}
+	data {
+		existing = "untouched"
+	}
+
+	lifecycle {
+		ignore_changes = ["data"]
+	}
+}
+
+resource "kubernetes_config_map_data" "test" {
+	name = "${kubernetes_config_map.test.metadata.0.name}"
+	data {
+		one = "changed"
+	}
+}
+`, name)
+}
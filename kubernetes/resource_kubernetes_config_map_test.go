@@ -1,7 +1,11 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"regexp"
 	"testing"
@@ -10,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
 	api "k8s.io/kubernetes/pkg/api/v1"
 	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
 )
@@ -93,6 +98,82 @@ func TestAccKubernetesConfigMap_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesConfigMap_binaryData(t *testing.T) {
+	var conf api.ConfigMap
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_config_map.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesConfigMapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapConfig_binaryData(name, `one = "AAEC/w=="`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "data.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data.one", "AAEC/w=="),
+				),
+			},
+			{
+				Config: testAccKubernetesConfigMapConfig_binaryData(name, `two = "/v8="`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "binary_data.two", "/v8="),
+				),
+			},
+		},
+	})
+}
+
+func TestCreateGetConfigMap_binaryData(t *testing.T) {
+	var created map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" && r.URL.Path == "/api/v1/namespaces/default/configmaps" {
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &created)
+		}
+		fmt.Fprint(w, `{"kind":"ConfigMap","apiVersion":"v1","metadata":{"name":"test","namespace":"default"},"data":{"text":"hello"},"binaryData":{"bin":"AAEC/w=="}}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfgMap := &api.ConfigMap{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "test", Namespace: "default"},
+		Data:       map[string]string{"text": "hello"},
+	}
+	_, err = createConfigMap(conn, cfgMap, map[string][]byte{"bin": {0, 1, 2, 255}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"kind":       "ConfigMap",
+		"apiVersion": "v1",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "default", "creationTimestamp": nil},
+		"data":       map[string]interface{}{"text": "hello"},
+		"binaryData": map[string]interface{}{"bin": "AAEC/w=="},
+	}
+	if !reflect.DeepEqual(created, expected) {
+		t.Fatalf("Unexpected request.\nExpected: %#v\nGiven:    %#v", expected, created)
+	}
+
+	out, err := getConfigMap(conn, "default", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "test" || out.Data["text"] != "hello" || !reflect.DeepEqual(out.BinaryData["bin"], []byte{0, 1, 2, 255}) {
+		t.Fatalf("Unexpected config map: %#v", out)
+	}
+}
+
 func TestAccKubernetesConfigMap_importBasic(t *testing.T) {
 	resourceName := "kubernetes_config_map.test"
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -167,6 +248,38 @@ func TestAccKubernetesConfigMap_importGeneratedName(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesConfigMap_ignoreKeys(t *testing.T) {
+	var conf api.ConfigMap
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_config_map.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesConfigMapDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesConfigMapConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					testAccCheckKubernetesConfigMapAddKey(&conf, "external", "by-controller"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccKubernetesConfigMapConfig_ignoreKeys(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesConfigMapExists("kubernetes_config_map.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "data.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "data.one", "changed"),
+					resource.TestCheckResourceAttr("kubernetes_config_map.test", "ignore_keys.#", "1"),
+					testAccCheckConfigMapData(&conf, map[string]string{"one": "changed", "external": "by-controller"}),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckConfigMapData(m *api.ConfigMap, expected map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.Data) == 0 {
@@ -224,6 +337,19 @@ func testAccCheckKubernetesConfigMapExists(n string, obj *api.ConfigMap) resourc
 	}
 }
 
+func testAccCheckKubernetesConfigMapAddKey(m *api.ConfigMap, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		m.Data[key] = value
+		out, err := conn.CoreV1().ConfigMaps(m.Namespace).Update(m)
+		if err != nil {
+			return err
+		}
+		*m = *out
+		return nil
+	}
+}
+
 func testAccKubernetesConfigMapConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_config_map" "test" {
@@ -277,6 +403,21 @@ resource "kubernetes_config_map" "test" {
 }`, name)
 }
 
+func testAccKubernetesConfigMapConfig_binaryData(name, binaryData string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+	metadata {
+		name = "%s"
+	}
+	data {
+		text = "hello"
+	}
+	binary_data {
+		%s
+	}
+}`, name, binaryData)
+}
+
 func testAccKubernetesConfigMapConfig_generatedName(prefix string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_config_map" "test" {
@@ -289,3 +430,25 @@ resource "kubernetes_config_map" "test" {
 	}
 }`, prefix)
 }
+
+func testAccKubernetesConfigMapConfig_ignoreKeys(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+	metadata {
+		annotations {
+			TestAnnotationOne = "one"
+			TestAnnotationTwo = "two"
+		}
+		labels {
+			TestLabelOne = "one"
+			TestLabelTwo = "two"
+			TestLabelThree = "three"
+		}
+		name = "%s"
+	}
+	data {
+		one = "changed"
+	}
+	ignore_keys = ["external"]
+}`, name)
+}
@@ -0,0 +1,136 @@
+package kubernetes
+
+import (
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	batchv1 "k8s.io/kubernetes/pkg/apis/batch/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func resourceKubernetesCronJobTrigger() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesCronJobTriggerCreate,
+		Read:   resourceKubernetesCronJobTriggerRead,
+		Delete: resourceKubernetesCronJobTriggerDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cron_job_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the cron job to create the job from.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the cron job, the job is created in it too.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values which, when changed, cause a new job to be created.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the job to complete when it's created, failing if the job fails. Bounded by the create timeout.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+			"job_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the created job.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesCronJobTriggerCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace := d.Get("namespace").(string)
+	cronJobName := d.Get("cron_job_name").(string)
+
+	log.Printf("[INFO] Reading cron job %s/%s", namespace, cronJobName)
+	cj, err := getCronJob(conn, namespace, cronJobName)
+	if err != nil {
+		return wrapError(err, "Failed to read cron job %s/%s", namespace, cronJobName)
+	}
+
+	job := jobFromCronJob(cj)
+	log.Printf("[INFO] Creating new job from cron job %s: %#v", cronJobName, job)
+	out, err := conn.BatchV1().Jobs(namespace).Create(&job)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Submitted new job: %#v", out)
+
+	d.SetId(buildId(out.ObjectMeta))
+	d.Set("job_name", out.Name)
+
+	if d.Get("wait_for_completion").(bool) {
+		log.Printf("[INFO] Waiting for job %s to complete", d.Id())
+		err = waitWithEvents(conn, out.ObjectMeta, "Job", func() error {
+			return resource.Retry(d.Timeout(schema.TimeoutCreate), waitForJobCompletionFunc(conn, out.Namespace, out.Name))
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Job %s completed", d.Id())
+	}
+
+	return resourceKubernetesCronJobTriggerRead(d, meta)
+}
+
+func resourceKubernetesCronJobTriggerRead(d *schema.ResourceData, meta interface{}) error {
+	// Triggering is a one-off action, the job may well be cleaned up
+	// by the cron job's history limits and mustn't be run again then
+	return nil
+}
+
+func resourceKubernetesCronJobTriggerDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// jobFromCronJob builds a job from the cron job's template
+// the way `kubectl create job --from=cronjob/...` does
+func jobFromCronJob(cj *cronJob) batchv1.Job {
+	annotations := map[string]string{
+		"cronjob.kubernetes.io/instantiate": "manual",
+	}
+	for k, v := range cj.Spec.JobTemplate.Metadata.Annotations {
+		annotations[k] = v
+	}
+	isController := true
+
+	return batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: cj.Metadata.Name + "-manual-",
+			Namespace:    cj.Metadata.Namespace,
+			Labels:       cj.Spec.JobTemplate.Metadata.Labels,
+			Annotations:  annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: cj.APIVersion,
+					Kind:       "CronJob",
+					Name:       cj.Metadata.Name,
+					UID:        cj.Metadata.UID,
+					Controller: &isController,
+				},
+			},
+		},
+		Spec: cj.Spec.JobTemplate.Spec,
+	}
+}
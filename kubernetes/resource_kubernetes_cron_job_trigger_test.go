@@ -0,0 +1,44 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobFromCronJob(t *testing.T) {
+	var cj cronJob
+	cj.APIVersion = "batch/v1beta1"
+	cj.Metadata = metav1.ObjectMeta{Name: "backup", Namespace: "ops", UID: "1234"}
+	cj.Spec.JobTemplate.Metadata = metav1.ObjectMeta{
+		Labels:      map[string]string{"app": "backup"},
+		Annotations: map[string]string{"team": "ops"},
+	}
+	parallelism := int32(2)
+	cj.Spec.JobTemplate.Spec.Parallelism = &parallelism
+
+	job := jobFromCronJob(&cj)
+
+	if job.GenerateName != "backup-manual-" || job.Namespace != "ops" {
+		t.Fatalf("Unexpected job metadata: %#v", job.ObjectMeta)
+	}
+	if !reflect.DeepEqual(job.Labels, map[string]string{"app": "backup"}) {
+		t.Fatalf("Unexpected labels: %q", job.Labels)
+	}
+	expected := map[string]string{"team": "ops", "cronjob.kubernetes.io/instantiate": "manual"}
+	if !reflect.DeepEqual(job.Annotations, expected) {
+		t.Fatalf("Expected annotations %q, given %q", expected, job.Annotations)
+	}
+	if len(job.OwnerReferences) != 1 {
+		t.Fatalf("Expected one owner reference, given %#v", job.OwnerReferences)
+	}
+	ref := job.OwnerReferences[0]
+	if ref.APIVersion != "batch/v1beta1" || ref.Kind != "CronJob" || ref.Name != "backup" || ref.UID != "1234" ||
+		ref.Controller == nil || !*ref.Controller {
+		t.Fatalf("Unexpected owner reference: %#v", ref)
+	}
+	if job.Spec.Parallelism == nil || *job.Spec.Parallelism != 2 {
+		t.Fatalf("Expected the template's spec, given %#v", job.Spec)
+	}
+}
@@ -0,0 +1,221 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+const defaultServiceAccountName = "default"
+
+func resourceKubernetesDefaultServiceAccount() *schema.Resource {
+	metadata := namespacedMetadataSchema("service account", false)
+	// The name is always "default", the resource takes over the account
+	// created by the service account controller in every namespace
+	metadata.Elem.(*schema.Resource).Schema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "Name of the service account. Always `default`.",
+		Computed:    true,
+	}
+
+	return &schema.Resource{
+		Create: resourceKubernetesDefaultServiceAccountCreate,
+		Read:   resourceKubernetesDefaultServiceAccountRead,
+		Exists: resourceKubernetesServiceAccountExists,
+		Update: resourceKubernetesDefaultServiceAccountUpdate,
+		Delete: resourceKubernetesDefaultServiceAccountDelete,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadata,
+			"automount_service_account_token": {
+				Type:        schema.TypeBool,
+				Description: "Whether pods running as this service account should have an API token automatically mounted.",
+				Optional:    true,
+				Default:     true,
+			},
+			"image_pull_secret": {
+				Type:        schema.TypeSet,
+				Description: "A list of references to secrets in the same namespace to use for pulling any images in pods that reference this Service Account. More info: http://kubernetes.io/docs/user-guide/secrets#manually-specifying-an-imagepullsecret",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"secret": {
+				Type:        schema.TypeSet,
+				Description: "A list of secrets allowed to be used by pods running using this Service Account. More info: http://kubernetes.io/docs/user-guide/secrets",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"default_secret_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesDefaultServiceAccountCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	metadata.Name = defaultServiceAccountName
+
+	// The service account controller creates the account (and its token)
+	// shortly after the namespace, which may have been created in the same apply
+	var svcAcc *api.ServiceAccount
+	var defaultSecretName string
+	err := resource.Retry(30*time.Second, func() *resource.RetryError {
+		var err error
+		svcAcc, err = conn.CoreV1().ServiceAccounts(metadata.Namespace).Get(metadata.Name, metav1.GetOptions{})
+		if err != nil {
+			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		for _, s := range svcAcc.Secrets {
+			if strings.HasPrefix(s.Name, metadata.Name+"-token-") {
+				defaultSecretName = s.Name
+				return nil
+			}
+		}
+		return resource.RetryableError(fmt.Errorf("Waiting for default secret of %q to appear", buildId(metadata)))
+	})
+	if err != nil {
+		return err
+	}
+	d.Set("default_secret_name", defaultSecretName)
+
+	// Merge patch leaves labels and annotations we don't manage in place
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": metadata.Annotations,
+			"labels":      metadata.Labels,
+		},
+		"automountServiceAccountToken": d.Get("automount_service_account_token").(bool),
+		"imagePullSecrets":             expandLocalObjectReferenceArray(d.Get("image_pull_secret").(*schema.Set).List()),
+		"secrets":                      expandServiceAccountSecrets(d.Get("secret").(*schema.Set).List(), defaultSecretName),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Taking over default service account %q: %v", buildId(metadata), string(data))
+	out, err := conn.CoreV1().ServiceAccounts(metadata.Namespace).Patch(metadata.Name, pkgApi.MergePatchType, data)
+	if err != nil {
+		return wrapError(err, "Failed to update default service account")
+	}
+	log.Printf("[INFO] Submitted updated default service account: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesDefaultServiceAccountRead(d, meta)
+}
+
+func resourceKubernetesDefaultServiceAccountRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading default service account %s", d.Id())
+	svcAcc, err := conn.CoreV1().ServiceAccounts(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received default service account: %#v", svcAcc)
+	err = d.Set("metadata", flattenMetadata(svcAcc.ObjectMeta))
+	if err != nil {
+		return err
+	}
+	// Unset means the token gets mounted
+	automount := svcAcc.AutomountServiceAccountToken == nil || *svcAcc.AutomountServiceAccountToken
+	d.Set("automount_service_account_token", automount)
+	d.Set("image_pull_secret", flattenLocalObjectReferenceArray(svcAcc.ImagePullSecrets))
+
+	defaultSecretName := d.Get("default_secret_name").(string)
+	log.Printf("[DEBUG] Default secret name is %q", defaultSecretName)
+	d.Set("secret", flattenServiceAccountSecrets(svcAcc.Secrets, defaultSecretName))
+
+	return nil
+}
+
+func resourceKubernetesDefaultServiceAccountUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("automount_service_account_token") {
+		ops = append(ops, &AddOperation{
+			Path:  "/automountServiceAccountToken",
+			Value: d.Get("automount_service_account_token").(bool),
+		})
+	}
+	if d.HasChange("image_pull_secret") {
+		v := d.Get("image_pull_secret").(*schema.Set).List()
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/imagePullSecrets",
+			Value: expandLocalObjectReferenceArray(v),
+		})
+	}
+	if d.HasChange("secret") {
+		v := d.Get("secret").(*schema.Set).List()
+		defaultSecretName := d.Get("default_secret_name").(string)
+
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/secrets",
+			Value: expandServiceAccountSecrets(v, defaultSecretName),
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating default service account %q: %v", d.Id(), string(data))
+	out, err := conn.CoreV1().ServiceAccounts(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	if err != nil {
+		return wrapError(err, "Failed to update default service account")
+	}
+	log.Printf("[INFO] Submitted updated default service account: %#v", out)
+
+	return resourceKubernetesDefaultServiceAccountRead(d, meta)
+}
+
+func resourceKubernetesDefaultServiceAccountDelete(d *schema.ResourceData, meta interface{}) error {
+	// The default service account is owned by the namespace,
+	// deleting it would just make the controller recreate it
+	log.Printf("[INFO] Removing default service account %s from state, the account itself is left in place", d.Id())
+
+	d.SetId("")
+	return nil
+}
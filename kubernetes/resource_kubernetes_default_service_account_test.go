@@ -0,0 +1,98 @@
+package kubernetes
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	api "k8s.io/kubernetes/pkg/api/v1"
+)
+
+func TestAccKubernetesDefaultServiceAccount_basic(t *testing.T) {
+	var conf api.ServiceAccount
+	namespace := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_default_service_account.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesNamespaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesDefaultServiceAccountConfig_basic(namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceAccountExists("kubernetes_default_service_account.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "metadata.0.name", "default"),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "metadata.0.namespace", namespace),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "metadata.0.labels.TestLabelOne", "one"),
+					testAccCheckMetaLabels(&conf.ObjectMeta, map[string]string{"TestLabelOne": "one"}),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "automount_service_account_token", "true"),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "secret.#", "0"),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "image_pull_secret.#", "1"),
+					testAccCheckServiceAccountImagePullSecrets(&conf, []*regexp.Regexp{
+						regexp.MustCompile("^registry$"),
+					}),
+					testAccCheckServiceAccountSecrets(&conf, []*regexp.Regexp{
+						regexp.MustCompile("^default-token-[a-z0-9]+$"),
+					}),
+				),
+			},
+			{
+				Config: testAccKubernetesDefaultServiceAccountConfig_modified(namespace),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceAccountExists("kubernetes_default_service_account.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "metadata.0.labels.%", "0"),
+					testAccCheckMetaLabels(&conf.ObjectMeta, map[string]string{}),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "automount_service_account_token", "false"),
+					resource.TestCheckResourceAttr("kubernetes_default_service_account.test", "image_pull_secret.#", "0"),
+					testAccCheckServiceAccountImagePullSecrets(&conf, []*regexp.Regexp{}),
+					testAccCheckServiceAccountSecrets(&conf, []*regexp.Regexp{
+						regexp.MustCompile("^default-token-[a-z0-9]+$"),
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesDefaultServiceAccountConfig_basic(namespace string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+  metadata {
+    name = "%s"
+  }
+}
+
+resource "kubernetes_default_service_account" "test" {
+  metadata {
+    namespace = "${kubernetes_namespace.test.metadata.0.name}"
+    labels {
+      TestLabelOne = "one"
+    }
+  }
+  image_pull_secret {
+    name = "registry"
+  }
+}
+`, namespace)
+}
+
+func testAccKubernetesDefaultServiceAccountConfig_modified(namespace string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+  metadata {
+    name = "%s"
+  }
+}
+
+resource "kubernetes_default_service_account" "test" {
+  metadata {
+    namespace = "${kubernetes_namespace.test.metadata.0.name}"
+  }
+  automount_service_account_token = false
+}
+`, namespace)
+}
@@ -0,0 +1,115 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func resourceKubernetesEviction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesEvictionCreate,
+		Read:   resourceKubernetesEvictionRead,
+		Delete: resourceKubernetesEvictionDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the pod to evict.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"pod_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the pod to evict.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"grace_period_seconds": {
+				Type:         schema.TypeInt,
+				Description:  "Period of time in seconds given to the pod to terminate gracefully. Defaults to the grace period of the pod.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDuration(0, 0),
+			},
+			"wait_for_termination": {
+				Type:        schema.TypeBool,
+				Description: "Wait until the pod is gone before the eviction is considered done.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values which, when changed, cause the pod to be evicted again.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"pod_uid": {
+				Type:        schema.TypeString,
+				Description: "UID of the evicted pod.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesEvictionCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace := d.Get("namespace").(string)
+	name := d.Get("pod_name").(string)
+
+	pod, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	var gracePeriod *int64
+	if v, ok := d.GetOk("grace_period_seconds"); ok {
+		seconds := int64(v.(int))
+		gracePeriod = &seconds
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	log.Printf("[INFO] Evicting pod %s/%s", namespace, name)
+	err = evictPod(conn, namespace, name, gracePeriod, timeout)
+	if err != nil {
+		return wrapError(err, "Failed to evict pod %s/%s", namespace, name)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", namespace, name, pod.UID))
+	d.Set("pod_uid", string(pod.UID))
+
+	if d.Get("wait_for_termination").(bool) {
+		err = waitWithEvents(conn, pod.ObjectMeta, "Pod", func() error {
+			return resource.Retry(timeout, waitForPodGoneFunc(conn, namespace, name, pod.UID, 0))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("[INFO] Pod %s/%s evicted", namespace, name)
+
+	return resourceKubernetesEvictionRead(d, meta)
+}
+
+func resourceKubernetesEvictionRead(d *schema.ResourceData, meta interface{}) error {
+	// An eviction is a one-off action, there's nothing to read back
+	return nil
+}
+
+func resourceKubernetesEvictionDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
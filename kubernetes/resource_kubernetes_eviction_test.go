@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesEviction_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesEvictionConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_eviction.test", "namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_eviction.test", "pod_name", name),
+					resource.TestCheckResourceAttr("kubernetes_eviction.test", "wait_for_termination", "true"),
+					resource.TestCheckResourceAttr("kubernetes_eviction.test", "grace_period_seconds", "5"),
+					resource.TestCheckResourceAttrSet("kubernetes_eviction.test", "pod_uid"),
+					testAccCheckKubernetesPodEvicted("kubernetes_eviction.test"),
+				),
+				// The evicted pod is gone, kubernetes_pod wants to recreate it
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPodEvicted(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		namespace := rs.Primary.Attributes["namespace"]
+		name := rs.Primary.Attributes["pod_name"]
+		pod, err := conn.CoreV1().Pods(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil && string(pod.UID) == rs.Primary.Attributes["pod_uid"] {
+			return fmt.Errorf("Pod %s/%s still exists", namespace, name)
+		}
+		return nil
+	}
+}
+
+func testAccKubernetesEvictionConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		container {
+			image = "nginx:1.7.9"
+			name  = "containername"
+		}
+	}
+}
+
+resource "kubernetes_eviction" "test" {
+	pod_name = "${kubernetes_pod.test.metadata.0.name}"
+	grace_period_seconds = 5
+}
+`, name)
+}
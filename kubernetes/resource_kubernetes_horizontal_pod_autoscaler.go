@@ -150,7 +150,7 @@ func resourceKubernetesHorizontalPodAutoscalerUpdate(d *schema.ResourceData, met
 	log.Printf("[INFO] Updating horizontal pod autoscaler %q: %v", name, string(data))
 	out, err := conn.AutoscalingV1().HorizontalPodAutoscalers(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update horizontal pod autoscaler: %s", err)
+		return wrapError(err, "Failed to update horizontal pod autoscaler")
 	}
 	log.Printf("[INFO] Submitted updated horizontal pod autoscaler: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -1,8 +1,10 @@
 package kubernetes
 
 import (
+	"bytes"
 	"fmt"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -10,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	api "k8s.io/kubernetes/pkg/api/v1"
 	batchv1 "k8s.io/kubernetes/pkg/apis/batch/v1"
 	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
 )
@@ -24,8 +27,13 @@ func resourceKubernetesJob() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("job", true),
+			"metadata":       namespacedMetadataSchema("job", true),
+			"mesh_injection": meshInjectionSchema(false),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the job owned by the cluster",
@@ -35,6 +43,105 @@ func resourceKubernetesJob() *schema.Resource {
 					Schema: jobSpecFields(),
 				},
 			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the job to complete when it's created, failing if the job fails. Bounded by the create timeout.",
+				Optional:    true,
+				Default:     false,
+			},
+			"delete_after_completion": {
+				Type:        schema.TypeBool,
+				Description: "Delete the job and its pods once it completed successfully, for clusters without the TTL after finished controller. The job is kept in state, so that it's not run again. Implies `wait_for_completion`.",
+				Optional:    true,
+				Default:     false,
+			},
+			"delete_propagation_policy": {
+				Type:         schema.TypeString,
+				Description:  "How the job's pods are deleted along with it. `Background` deletes them after the job, `Foreground` before the job, `Orphan` leaves them running. Unless orphaned, the deletion waits for the pods to be gone.",
+				Optional:     true,
+				Default:      string(metav1.DeletePropagationBackground),
+				ValidateFunc: validateAttributeValueIsIn([]string{"Background", "Foreground", "Orphan"}),
+			},
+			"logs_limit_bytes": {
+				Type:         schema.TypeInt,
+				Description:  "Maximum size of the logs collected in `logs`, in bytes. The newest pods' logs are kept, each from its beginning. No limit when 0.",
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateNonNegativeInteger,
+			},
+			"logs": {
+				Type:        schema.TypeString,
+				Description: "Logs of the job's pods, collected once the job finished when `wait_for_completion` or `delete_after_completion` is set.",
+				Computed:    true,
+			},
+			"status": {
+				Type:        schema.TypeList,
+				Description: "Most recently observed status of the job.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"active": {
+							Type:        schema.TypeInt,
+							Description: "Number of actively running pods.",
+							Computed:    true,
+						},
+						"succeeded": {
+							Type:        schema.TypeInt,
+							Description: "Number of pods which reached phase Succeeded.",
+							Computed:    true,
+						},
+						"failed": {
+							Type:        schema.TypeInt,
+							Description: "Number of pods which reached phase Failed.",
+							Computed:    true,
+						},
+						"start_time": {
+							Type:        schema.TypeString,
+							Description: "When the job was acknowledged by the job controller (RFC 3339).",
+							Computed:    true,
+						},
+						"completion_time": {
+							Type:        schema.TypeString,
+							Description: "When the job was completed (RFC 3339). Empty until the job completed successfully.",
+							Computed:    true,
+						},
+						"condition": {
+							Type:        schema.TypeList,
+							Description: "Latest available observations of the job's state, e.g. `Complete` or `Failed`.",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:        schema.TypeString,
+										Description: "Type of the condition, `Complete` or `Failed`.",
+										Computed:    true,
+									},
+									"status": {
+										Type:        schema.TypeString,
+										Description: "Status of the condition, one of `True`, `False` or `Unknown`.",
+										Computed:    true,
+									},
+									"reason": {
+										Type:        schema.TypeString,
+										Description: "Brief reason for the condition's last transition.",
+										Computed:    true,
+									},
+									"message": {
+										Type:        schema.TypeString,
+										Description: "Human readable message indicating details about the last transition.",
+										Computed:    true,
+									},
+									"last_transition_time": {
+										Type:        schema.TypeString,
+										Description: "Last time the condition transitioned from one status to another (RFC 3339).",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -47,15 +154,18 @@ func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return err
 	}
+	expandExplicitZeroValues(d, "spec.0.template.0.", &spec.Template.Spec)
+	spec.Template.Annotations = expandMeshInjection(d.Get("mesh_injection").([]interface{}), spec.Template.Annotations)
 
-	job := batchv1.Job{
+	job := jobWithNewerSpec{
 		ObjectMeta: metadata,
 		Spec:       spec,
 	}
 
 	log.Printf("[INFO] Creating new job: %#v", job)
 
-	out, err := conn.BatchV1().Jobs(metadata.Namespace).Create(&job)
+	var out jobWithNewerSpec
+	err = objectKinds["Job"].create(conn, metadata.Namespace, &job, &out)
 	if err != nil {
 		return err
 	}
@@ -63,6 +173,35 @@ func resourceKubernetesJobCreate(d *schema.ResourceData, meta interface{}) error
 
 	d.SetId(buildId(out.ObjectMeta))
 
+	// The job can only be deleted after completion once it completed
+	if d.Get("wait_for_completion").(bool) || d.Get("delete_after_completion").(bool) {
+		log.Printf("[INFO] Waiting for job %s to complete", d.Id())
+		err = waitWithEvents(conn, out.ObjectMeta, "Job", func() error {
+			return resource.Retry(d.Timeout(schema.TimeoutCreate), waitForJobCompletionFunc(conn, out.Namespace, out.Name))
+		})
+
+		// Logs of failed jobs are the most interesting ones, collect them either way
+		logs, logsErr := jobPodLogs(conn, &out, int64(d.Get("logs_limit_bytes").(int)))
+		if logsErr != nil {
+			log.Printf("[WARN] Failed to collect logs of job %s: %s", d.Id(), logsErr)
+		}
+		d.Set("logs", logs)
+
+		if err != nil {
+			return err
+		}
+		log.Printf("[INFO] Job %s completed", d.Id())
+
+		if d.Get("delete_after_completion").(bool) {
+			log.Printf("[INFO] Deleting completed job %s", d.Id())
+			propagation := metav1.DeletePropagationBackground
+			err = conn.BatchV1().Jobs(out.Namespace).Delete(out.Name, &metav1.DeleteOptions{PropagationPolicy: &propagation})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return resourceKubernetesJobRead(d, meta)
 }
 
@@ -93,6 +232,11 @@ func resourceKubernetesJobUpdate(d *schema.ResourceData, meta interface{}) error
 
 	out, err := conn.BatchV1().Jobs(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
+		// Nothing left to update once the job was deleted after completion
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 && d.Get("delete_after_completion").(bool) {
+			log.Printf("[INFO] Job %s was deleted after completion, only updating state", d.Id())
+			return nil
+		}
 		return err
 	}
 	log.Printf("[INFO] Submitted updated job: %#v", out)
@@ -110,8 +254,19 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Printf("[INFO] Reading job %s", name)
-	job, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+	var job jobWithNewerSpec
+	err = objectKinds["Job"].get(conn, namespace, name, &job)
 	if err != nil {
+		// Finished jobs may be cleaned up by the cluster (TTL after finished)
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			if d.Get("delete_after_completion").(bool) {
+				log.Printf("[INFO] Job %s was deleted after completion, keeping last known state", d.Id())
+				return nil
+			}
+			log.Printf("[INFO] Job %s is gone, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
@@ -141,6 +296,15 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// Only take over the injection annotation when it's managed via mesh_injection,
+	// as an injector setting it would otherwise replace the job
+	if len(d.Get("mesh_injection").([]interface{})) > 0 {
+		err = d.Set("mesh_injection", flattenMeshInjection(job.Spec.Template.Annotations))
+		if err != nil {
+			return err
+		}
+	}
+
 	jobSpec, err := flattenJobSpec(job.Spec)
 	if err != nil {
 		return err
@@ -151,6 +315,11 @@ func resourceKubernetesJobRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	err = d.Set("status", flattenJobStatus(job.Status))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -162,30 +331,17 @@ func resourceKubernetesJobDelete(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	log.Printf("[INFO] Deleting job: %#v", name)
-	err = conn.BatchV1().Jobs(namespace).Delete(name, nil)
+	propagation := metav1.DeletionPropagation(d.Get("delete_propagation_policy").(string))
+	err = deleteJob(conn, namespace, name, propagation, d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return err
-	}
-
-	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
-		_, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
-		if err != nil {
-			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
-				return nil
-			}
-			return resource.NonRetryableError(err)
+		// Already deleted after completion
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 && d.Get("delete_after_completion").(bool) {
+			d.SetId("")
+			return nil
 		}
-
-		e := fmt.Errorf("Job %s still exists", name)
-		return resource.RetryableError(e)
-	})
-	if err != nil {
 		return err
 	}
 
-	log.Printf("[INFO] Job %s deleted", name)
-
 	d.SetId("")
 	return nil
 }
@@ -202,9 +358,158 @@ func resourceKubernetesJobExists(d *schema.ResourceData, meta interface{}) (bool
 	_, err = conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
 	if err != nil {
 		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
-			return false, nil
+			return d.Get("delete_after_completion").(bool), nil
 		}
 		log.Printf("[DEBUG] Received error: %#v", err)
 	}
 	return true, err
 }
+
+func waitForJobCompletionFunc(conn *kubernetes.Clientset, namespace, name string) resource.RetryFunc {
+	return func() *resource.RetryError {
+		job, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		for _, c := range job.Status.Conditions {
+			if c.Status != api.ConditionTrue {
+				continue
+			}
+			switch c.Type {
+			case batchv1.JobComplete:
+				return nil
+			case batchv1.JobFailed:
+				return resource.NonRetryableError(fmt.Errorf("Job %s/%s failed: %s: %s", namespace, name, c.Reason, c.Message))
+			}
+		}
+
+		e := fmt.Errorf("Job %s/%s is not complete yet (%d active, %d succeeded, %d failed)",
+			namespace, name, job.Status.Active, job.Status.Succeeded, job.Status.Failed)
+		log.Printf("[DEBUG] %s", e)
+		return resource.RetryableError(e)
+	}
+}
+
+// jobPodLogs collects logs of all containers of the job's pods, oldest pod first.
+// Each container's logs are headed by its pod and container name if there are several.
+// With a limit, the server is asked for no more than the bytes left, newest pod first.
+func jobPodLogs(conn *kubernetes.Clientset, job *jobWithNewerSpec, limitBytes int64) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return "", err
+	}
+	pods, err := conn.CoreV1().Pods(job.Namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", err
+	}
+	items := pods.Items
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].CreationTimestamp.Before(items[j].CreationTimestamp)
+	})
+
+	streams := 0
+	for _, pod := range items {
+		streams += len(pod.Spec.Containers)
+	}
+
+	parts := make([][]byte, 0, streams)
+	remaining := limitBytes
+	for i := len(items) - 1; i >= 0 && (limitBytes == 0 || remaining > 0); i-- {
+		pod := items[i]
+		for j := len(pod.Spec.Containers) - 1; j >= 0 && (limitBytes == 0 || remaining > 0); j-- {
+			c := pod.Spec.Containers[j]
+			opts := &api.PodLogOptions{Container: c.Name}
+			if limitBytes > 0 {
+				opts.LimitBytes = ptrToInt64(remaining)
+			}
+			raw, err := conn.CoreV1().Pods(job.Namespace).GetLogs(pod.Name, opts).Do().Raw()
+			if err != nil {
+				return "", wrapError(err, "Failed to get logs of container %s of pod %s", c.Name, pod.Name)
+			}
+			remaining -= int64(len(raw))
+
+			var buf bytes.Buffer
+			if streams > 1 {
+				fmt.Fprintf(&buf, "==> %s/%s <==\n", pod.Name, c.Name)
+			}
+			buf.Write(raw)
+			parts = append(parts, buf.Bytes())
+		}
+	}
+
+	var buf bytes.Buffer
+	for i := len(parts) - 1; i >= 0; i-- {
+		buf.Write(parts[i])
+	}
+	return buf.String(), nil
+}
+
+// deleteJob deletes the job and waits until it's gone, and unless
+// they're orphaned until its pods are too, so that a replacing job
+// doesn't run alongside them
+func deleteJob(conn *kubernetes.Clientset, namespace, name string, propagation metav1.DeletionPropagation, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	job, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting job %#v with propagation policy %s", name, propagation)
+	err = conn.BatchV1().Jobs(namespace).Delete(name, &metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil {
+		return err
+	}
+
+	err = waitWithEvents(conn, job.ObjectMeta, "Job", func() error {
+		return resource.Retry(time.Until(deadline), func() *resource.RetryError {
+			_, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+					return nil
+				}
+				return resource.NonRetryableError(err)
+			}
+
+			e := fmt.Errorf("Job %s still exists", name)
+			return resource.RetryableError(e)
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Job %s deleted", name)
+
+	if propagation == metav1.DeletePropagationOrphan {
+		return nil
+	}
+
+	// With background propagation the pods are deleted after the job
+	return waitWithEvents(conn, job.ObjectMeta, "Job", func() error {
+		return resource.Retry(time.Until(deadline), func() *resource.RetryError {
+			pods, err := conn.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			if len(pods.Items) > 0 {
+				return resource.RetryableError(fmt.Errorf("%d pods of job %s still exist", len(pods.Items), name))
+			}
+			return nil
+		})
+	})
+}
+
+// jobWithNewerSpec is a job including pod spec fields
+// which the vendored API types predate
+type jobWithNewerSpec struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              jobSpec           `json:"spec,omitempty"`
+	Status            batchv1.JobStatus `json:"status,omitempty"`
+}
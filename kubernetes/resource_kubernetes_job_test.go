@@ -0,0 +1,615 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+	batchv1 "k8s.io/kubernetes/pkg/apis/batch/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesJob_basic(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "metadata.0.labels.%", "0"),
+					resource.TestCheckResourceAttrSet("kubernetes_job.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_job.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("kubernetes_job.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.active_deadline_seconds", "120"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.completions", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.parallelism", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.template.0.restart_policy", "Never"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.template.0.container.0.image", "alpine"),
+				),
+			},
+			{
+				Config: testAccKubernetesJobConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.active_deadline_seconds", "240"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_importBasic(t *testing.T) {
+	resourceName := "kubernetes_job.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_basic(name),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_completion", "delete_after_completion", "delete_propagation_policy", "logs", "logs_limit_bytes", "status"},
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_waitForCompletion(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_waitForCompletion(name, 120, `["echo", "hello"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "wait_for_completion", "true"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "logs", "hello\n"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "status.0.succeeded", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "status.0.active", "0"),
+					resource.TestCheckResourceAttrSet("kubernetes_job.test", "status.0.start_time"),
+					resource.TestCheckResourceAttrSet("kubernetes_job.test", "status.0.completion_time"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "status.0.condition.0.type", "Complete"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "status.0.condition.0.status", "True"),
+					func(s *terraform.State) error {
+						if conf.Status.Succeeded != 1 {
+							return fmt.Errorf("Expected job to have succeeded, got %#v", conf.Status)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_deleteAfterCompletion(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_deleteAfterCompletion(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_job.test", "delete_after_completion", "true"),
+					testAccCheckKubernetesJobDestroy,
+				),
+			},
+			// The deleted job must not be run again
+			{
+				Config:   testAccKubernetesJobConfig_deleteAfterCompletion(name),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_replaceOnTemplateChange(t *testing.T) {
+	var before, after batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_waitForCompletion(name, 120, `["echo", "hello"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &before),
+				),
+			},
+			{
+				Config: testAccKubernetesJobConfig_waitForCompletion(name, 120, `["echo", "bye"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &after),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "logs", "bye\n"),
+					func(s *terraform.State) error {
+						if before.UID == after.UID {
+							return fmt.Errorf("Expected job to be replaced, UID is still %s", after.UID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_deletePropagationForeground(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckKubernetesJobDestroy(s); err != nil {
+				return err
+			}
+			return testAccCheckKubernetesJobPodsDestroyed(name)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfig_deletePropagation(name, "Foreground"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_job.test", "delete_propagation_policy", "Foreground"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_waitForCompletionFailed(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesJobConfig_waitForCompletion(name, 1, `["sleep", "60"]`),
+				ExpectError: regexp.MustCompile("DeadlineExceeded"),
+			},
+		},
+	})
+}
+
+func TestWaitForJobCompletionFunc(t *testing.T) {
+	var status string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"kind":"Job","apiVersion":"batch/v1","metadata":{"name":"migrate","namespace":"default"},"spec":{"template":{"spec":{"containers":[]}}},"status":%s}`, status)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := waitForJobCompletionFunc(conn, "default", "migrate")
+
+	status = `{"active":1}`
+	if err := f(); err == nil || !err.Retryable {
+		t.Fatalf("Expected running job to be retried, got %#v", err)
+	}
+
+	status = `{"conditions":[{"type":"Failed","status":"True","reason":"DeadlineExceeded","message":"Job was active longer than specified deadline"}],"failed":1}`
+	if err := f(); err == nil || err.Retryable || !strings.Contains(err.Err.Error(), "DeadlineExceeded") {
+		t.Fatalf("Expected failed job to fail, got %#v", err)
+	}
+
+	status = `{"conditions":[{"type":"Complete","status":"True"}],"succeeded":1}`
+	if err := f(); err != nil {
+		t.Fatalf("Expected complete job to succeed, got %#v", err)
+	}
+}
+
+func TestDeleteJob_waitsForPods(t *testing.T) {
+	deleted := false
+	podLists := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/apis/batch/v1/namespaces/default/jobs/migrate" && r.Method == "DELETE":
+			body, _ := ioutil.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"propagationPolicy":"Background"`) {
+				t.Error("Expected background propagation")
+			}
+			deleted = true
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+		case r.URL.Path == "/apis/batch/v1/namespaces/default/jobs/migrate":
+			if deleted {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+				return
+			}
+			fmt.Fprint(w, `{"kind":"Job","apiVersion":"batch/v1","metadata":{"name":"migrate","namespace":"default"},"spec":{"selector":{"matchLabels":{"controller-uid":"1234"}},"template":{"spec":{"containers":[]}}}}`)
+		case r.URL.Path == "/api/v1/namespaces/default/pods":
+			if r.URL.Query().Get("labelSelector") != "controller-uid=1234" {
+				t.Errorf("Unexpected label selector %q", r.URL.Query().Get("labelSelector"))
+			}
+			podLists++
+			if podLists == 1 {
+				fmt.Fprint(w, `{"kind":"PodList","apiVersion":"v1","items":[{"metadata":{"name":"migrate-1"}}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"kind":"PodList","apiVersion":"v1","items":[]}`)
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = deleteJob(conn, "default", "migrate", metav1.DeletePropagationBackground, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if podLists != 2 {
+		t.Fatalf("Expected the deletion to wait for the job's pods, listed them %d times", podLists)
+	}
+}
+
+func TestJobSpecFields_immutable(t *testing.T) {
+	s := jobSpecFields()
+	image := s["template"].Elem.(*schema.Resource).Schema["container"].Elem.(*schema.Resource).Schema["image"]
+	if !image.ForceNew {
+		t.Fatal("Expected changes to the pod template to replace the job")
+	}
+	if !s["completions"].ForceNew {
+		t.Fatal("Expected changes to completions to replace the job")
+	}
+	if s["parallelism"].ForceNew || s["active_deadline_seconds"].ForceNew {
+		t.Fatal("Expected parallelism and active_deadline_seconds to be updated in place")
+	}
+}
+
+func TestJobPodLogs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/pods":
+			if r.URL.Query().Get("labelSelector") != "controller-uid=1234" {
+				t.Errorf("Unexpected label selector %q", r.URL.Query().Get("labelSelector"))
+			}
+			fmt.Fprint(w, `{"kind":"PodList","apiVersion":"v1","items":[`+
+				`{"metadata":{"name":"migrate-2","creationTimestamp":"2017-09-01T10:01:00Z"},"spec":{"containers":[{"name":"migrate"}]}},`+
+				`{"metadata":{"name":"migrate-1","creationTimestamp":"2017-09-01T10:00:00Z"},"spec":{"containers":[{"name":"migrate"}]}}]}`)
+		case "/api/v1/namespaces/default/pods/migrate-1/log":
+			writeLimited(w, r, "connection refused\n")
+		case "/api/v1/namespaces/default/pods/migrate-2/log":
+			writeLimited(w, r, "applied 3 migrations\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := &jobWithNewerSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+		Spec: jobSpec{JobSpec: batchv1.JobSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": "1234"}},
+		}},
+	}
+
+	logs, err := jobPodLogs(conn, job, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "==> migrate-1/migrate <==\nconnection refused\n==> migrate-2/migrate <==\napplied 3 migrations\n"
+	if logs != expected {
+		t.Fatalf("Unexpected logs.\nExpected: %q\nGiven:    %q", expected, logs)
+	}
+
+	logs, err = jobPodLogs(conn, job, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logs != "==> migrate-2/migrate <==\napplied 3 " {
+		t.Fatalf("Expected only the newest pod's logs to be requested up to the limit, got %q", logs)
+	}
+
+	logs, err = jobPodLogs(conn, job, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "==> migrate-1/migrate <==\nconnectio==> migrate-2/migrate <==\napplied 3 migrations\n"
+	if logs != expected {
+		t.Fatalf("Unexpected limited logs.\nExpected: %q\nGiven:    %q", expected, logs)
+	}
+}
+
+// writeLimited writes the logs cut to the limitBytes the API is asked for
+func writeLimited(w http.ResponseWriter, r *http.Request, logs string) {
+	if v := r.URL.Query().Get("limitBytes"); v != "" {
+		limit, _ := strconv.Atoi(v)
+		if limit < len(logs) {
+			logs = logs[:limit]
+		}
+	}
+	fmt.Fprint(w, logs)
+}
+
+func TestAccKubernetesJob_gke_with_nodeSelector(t *testing.T) {
+	var conf batchv1.Job
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	region := os.Getenv("GOOGLE_REGION")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t); skipIfNotRunningInGke(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesJobConfigNodeSelector(name, region),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesJobExists("kubernetes_job.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.template.0.node_selector.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_job.test", "spec.0.template.0.node_selector.failure-domain.beta.kubernetes.io/region", region),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesJob_restartPolicyAlways(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKubernetesJobConfig_restartPolicy(name, "Always"),
+				ExpectError: regexp.MustCompile("restart_policy\" must contain a value from"),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesJobDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_job" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Job still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesJobPodsDestroyed(name string) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	pods, err := conn.CoreV1().Pods("default").List(metav1.ListOptions{LabelSelector: "job-name=" + name})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) > 0 {
+		return fmt.Errorf("Pods of job %s still exist: %d", name, len(pods.Items))
+	}
+	return nil
+}
+
+func testAccCheckKubernetesJobExists(n string, obj *batchv1.Job) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.BatchV1().Jobs(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesJobConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		active_deadline_seconds = 120
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = ["echo", "hello"]
+			}
+			restart_policy = "Never"
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesJobConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		active_deadline_seconds = 240
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = ["echo", "hello"]
+			}
+			restart_policy = "Never"
+		}
+	}
+}`, name)
+}
+
+func testAccKubernetesJobConfig_waitForCompletion(name string, deadline int, command string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		active_deadline_seconds = %d
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = %s
+			}
+			restart_policy = "Never"
+		}
+	}
+	wait_for_completion = true
+}`, name, deadline, command)
+}
+
+func testAccKubernetesJobConfig_deleteAfterCompletion(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = ["echo", "hello"]
+			}
+			restart_policy = "Never"
+		}
+	}
+	delete_after_completion = true
+}`, name)
+}
+
+func testAccKubernetesJobConfig_deletePropagation(name, policy string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = ["echo", "hello"]
+			}
+			restart_policy = "Never"
+		}
+	}
+	wait_for_completion = true
+	delete_propagation_policy = "%s"
+}`, name, policy)
+}
+
+func testAccKubernetesJobConfig_restartPolicy(name, policy string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = ["echo", "hello"]
+			}
+			restart_policy = "%s"
+		}
+	}
+}`, name, policy)
+}
+
+func testAccKubernetesJobConfigNodeSelector(name, region string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_job" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		template {
+			container {
+				name = "hello"
+				image = "alpine"
+				command = ["echo", "hello"]
+			}
+			node_selector {
+				"failure-domain.beta.kubernetes.io/region" = "%s"
+			}
+			restart_policy = "Never"
+		}
+	}
+}`, name, region)
+}
@@ -0,0 +1,11 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesLabels() *schema.Resource {
+	return objectMetaMapResource("labels",
+		"Labels to set on the object. Labels which are not listed here are left untouched. More info: http://kubernetes.io/docs/user-guide/labels",
+		validateLabels)
+}
@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesLabels_basic(t *testing.T) {
+	key := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_labels.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesObjectMetaMapDestroy("kubernetes_labels", "labels"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesLabelsConfig_basic(key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_labels.test", "kind", "Namespace"),
+					resource.TestCheckResourceAttr("kubernetes_labels.test", "name", "default"),
+					resource.TestCheckResourceAttr("kubernetes_labels.test", "labels.%", "2"),
+					resource.TestCheckResourceAttr("kubernetes_labels.test", "labels."+key+"-one", "one"),
+					resource.TestCheckResourceAttr("kubernetes_labels.test", "labels."+key+"-two", "two"),
+					testAccCheckKubernetesObjectMetaMap("Namespace", "", "default", "labels", map[string]string{key + "-one": "one", key + "-two": "two"}),
+				),
+			},
+			{
+				Config: testAccKubernetesLabelsConfig_modified(key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_labels.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_labels.test", "labels."+key+"-one", "changed"),
+					testAccCheckKubernetesObjectMetaMap("Namespace", "", "default", "labels", map[string]string{key + "-one": "changed"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesObjectMetaMap(kind, namespace, name, field string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		objMeta, err := getObjectMeta(conn, kind, namespace, name)
+		if err != nil {
+			return err
+		}
+
+		// Other keys may be managed elsewhere, only look at ours
+		current := objectMetaMap(objMeta, field)
+		for k, v := range expected {
+			if current[k] != v {
+				return fmt.Errorf("%s %q: expected %s %q to be %q, got %q", kind, name, field, k, v, current[k])
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesObjectMetaMapDestroy(resourceType, field string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			kind, namespace, name, err := objectIdParts(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+
+			objMeta, err := getObjectMeta(conn, kind, namespace, name)
+			if err != nil {
+				continue
+			}
+			for k := range objectMetaMap(objMeta, field) {
+				if _, ok := rs.Primary.Attributes[field+"."+k]; ok {
+					return fmt.Errorf("%s %q still exists on %s", field, k, rs.Primary.ID)
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccKubernetesLabelsConfig_basic(key string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_labels" "test" {
+  kind = "Namespace"
+  name = "default"
+  labels {
+    "%s-one" = "one"
+    "%s-two" = "two"
+  }
+}
+`, key, key)
+}
+
+func testAccKubernetesLabelsConfig_modified(key string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_labels" "test" {
+  kind = "Namespace"
+  name = "default"
+  labels {
+    "%s-one" = "changed"
+  }
+}
+`, key)
+}
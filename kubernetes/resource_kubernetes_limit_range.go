@@ -94,7 +94,7 @@ func resourceKubernetesLimitRangeCreate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Creating new limit range: %#v", limitRange)
 	out, err := conn.CoreV1().LimitRanges(metadata.Namespace).Create(&limitRange)
 	if err != nil {
-		return fmt.Errorf("Failed to create limit range: %s", err)
+		return wrapError(err, "Failed to create limit range")
 	}
 	log.Printf("[INFO] Submitted new limit range: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -155,7 +155,7 @@ func resourceKubernetesLimitRangeUpdate(d *schema.ResourceData, meta interface{}
 	log.Printf("[INFO] Updating limit range %q: %v", name, string(data))
 	out, err := conn.CoreV1().LimitRanges(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update limit range: %s", err)
+		return wrapError(err, "Failed to update limit range")
 	}
 	log.Printf("[INFO] Submitted updated limit range: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -117,7 +117,10 @@ func resourceKubernetesNamespaceDelete(d *schema.ResourceData, meta interface{})
 			return out, statusPhase, nil
 		},
 	}
-	_, err = stateConf.WaitForState()
+	err = waitWithEvents(conn, meta_v1.ObjectMeta{Name: name}, "Namespace", func() error {
+		_, err := stateConf.WaitForState()
+		return err
+	})
 	if err != nil {
 		return err
 	}
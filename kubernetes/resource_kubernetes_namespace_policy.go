@@ -0,0 +1,424 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+	"k8s.io/kubernetes/pkg/client/clientset_generated/clientset/scheme"
+)
+
+func resourceKubernetesNamespacePolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesNamespacePolicyCreate,
+		Read:   resourceKubernetesNamespacePolicyRead,
+		Exists: resourceKubernetesNamespacePolicyExists,
+		Update: resourceKubernetesNamespacePolicyUpdate,
+		Delete: resourceKubernetesNamespacePolicyDelete,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("namespace policy", false),
+			"tier": {
+				Type:        schema.TypeString,
+				Description: "Name of the tier from `tier_limits` to apply to the namespace.",
+				Required:    true,
+			},
+			"tier_limits": {
+				Type:        schema.TypeList,
+				Description: "Limits of each tier, keyed by tier name.",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "Name of the tier.",
+							Required:    true,
+						},
+						"quota": {
+							Type:         schema.TypeMap,
+							Description:  "The set of hard limits for each named resource in the namespace, e.g. `cpu`, `memory` or `pods`.",
+							Optional:     true,
+							ValidateFunc: validateResourceList,
+						},
+						"default_limit": {
+							Type:         schema.TypeMap,
+							Description:  "Default resource limits of containers that don't specify any.",
+							Optional:     true,
+							ValidateFunc: validateResourceList,
+						},
+						"default_request": {
+							Type:         schema.TypeMap,
+							Description:  "Default resource requests of containers that don't specify any.",
+							Optional:     true,
+							ValidateFunc: validateResourceList,
+						},
+					},
+				},
+			},
+			"network_isolation": {
+				Type:        schema.TypeBool,
+				Description: "Only allow ingress traffic to pods of the namespace from pods in the same namespace.",
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+func resourceKubernetesNamespacePolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	if metadata.Name == "" {
+		return fmt.Errorf("metadata.0.name is required for namespace policies")
+	}
+	tier, err := namespacePolicyTier(d)
+	if err != nil {
+		return err
+	}
+
+	quotaSpec, err := expandNamespacePolicyQuotaSpec(tier)
+	if err != nil {
+		return err
+	}
+	quota := api.ResourceQuota{
+		ObjectMeta: metadata,
+		Spec:       quotaSpec,
+	}
+	log.Printf("[INFO] Creating new resource quota: %#v", quota)
+	out, err := conn.CoreV1().ResourceQuotas(metadata.Namespace).Create(&quota)
+	if err != nil {
+		return wrapError(err, "Failed to create resource quota")
+	}
+	log.Printf("[INFO] Submitted new resource quota: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	limitRangeSpec, err := expandNamespacePolicyLimitRangeSpec(tier)
+	if err != nil {
+		return err
+	}
+	limitRange := api.LimitRange{
+		ObjectMeta: metadata,
+		Spec:       limitRangeSpec,
+	}
+	log.Printf("[INFO] Creating new limit range: %#v", limitRange)
+	lr, err := conn.CoreV1().LimitRanges(metadata.Namespace).Create(&limitRange)
+	if err != nil {
+		return wrapError(err, "Failed to create limit range")
+	}
+	log.Printf("[INFO] Submitted new limit range: %#v", lr)
+
+	if d.Get("network_isolation").(bool) {
+		err = createNamespacePolicyNetworkPolicy(conn, metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKubernetesNamespacePolicyRead(d, meta)
+}
+
+func resourceKubernetesNamespacePolicyRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading resource quota %s", name)
+	quota, err := conn.CoreV1().ResourceQuotas(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received resource quota: %#v", quota)
+
+	err = d.Set("metadata", flattenMetadata(quota.ObjectMeta))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading limit range %s", name)
+	limitRange, err := conn.CoreV1().LimitRanges(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			log.Printf("[DEBUG] Received error: %#v", err)
+			return err
+		}
+		limitRange = &api.LimitRange{}
+	}
+	log.Printf("[INFO] Received limit range: %#v", limitRange)
+
+	// Reflect the live objects in the selected tier, so drift shows up as a diff
+	tiers := d.Get("tier_limits").([]interface{})
+	for i, t := range tiers {
+		tier := t.(map[string]interface{})
+		if tier["name"].(string) != d.Get("tier").(string) {
+			continue
+		}
+		tier["quota"] = flattenResourceList(quota.Spec.Hard)
+		tier["default_limit"] = map[string]interface{}{}
+		for _, l := range limitRange.Spec.Limits {
+			if l.Type != api.LimitTypeContainer {
+				continue
+			}
+			tier["default_limit"] = flattenResourceList(l.Default)
+			// The API server defaults requests to limits when only the latter are set
+			if len(tier["default_request"].(map[string]interface{})) > 0 || !resourceListEquals(l.Default, l.DefaultRequest) {
+				tier["default_request"] = flattenResourceList(l.DefaultRequest)
+			}
+		}
+		tiers[i] = tier
+	}
+	err = d.Set("tier_limits", tiers)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading network policy %s", name)
+	_, err = networkPolicies(conn, namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			log.Printf("[DEBUG] Received error: %#v", err)
+			return err
+		}
+		d.Set("network_isolation", false)
+		return nil
+	}
+	d.Set("network_isolation", true)
+
+	return nil
+}
+
+func resourceKubernetesNamespacePolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	tier, err := namespacePolicyTier(d)
+	if err != nil {
+		return err
+	}
+	tierChanged := d.HasChange("tier") || d.HasChange("tier_limits")
+
+	quotaOps := patchMetadata("metadata.0.", "/metadata/", d)
+	if tierChanged {
+		spec, err := expandNamespacePolicyQuotaSpec(tier)
+		if err != nil {
+			return err
+		}
+		quotaOps = append(quotaOps, &ReplaceOperation{
+			Path:  "/spec",
+			Value: spec,
+		})
+	}
+	data, err := quotaOps.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating resource quota %q: %v", name, string(data))
+	out, err := conn.CoreV1().ResourceQuotas(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	if err != nil {
+		return wrapError(err, "Failed to update resource quota")
+	}
+	log.Printf("[INFO] Submitted updated resource quota: %#v", out)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	limitRangeSpec, err := expandNamespacePolicyLimitRangeSpec(tier)
+	if err != nil {
+		return err
+	}
+	_, err = conn.CoreV1().LimitRanges(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			return err
+		}
+		limitRange := api.LimitRange{
+			ObjectMeta: metadata,
+			Spec:       limitRangeSpec,
+		}
+		log.Printf("[INFO] Recreating limit range: %#v", limitRange)
+		_, err = conn.CoreV1().LimitRanges(namespace).Create(&limitRange)
+		if err != nil {
+			return wrapError(err, "Failed to create limit range")
+		}
+	} else {
+		lrOps := patchMetadata("metadata.0.", "/metadata/", d)
+		if tierChanged {
+			lrOps = append(lrOps, &ReplaceOperation{
+				Path:  "/spec",
+				Value: limitRangeSpec,
+			})
+		}
+		data, err := lrOps.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("Failed to marshal update operations: %s", err)
+		}
+		log.Printf("[INFO] Updating limit range %q: %v", name, string(data))
+		_, err = conn.CoreV1().LimitRanges(namespace).Patch(name, pkgApi.JSONPatchType, data)
+		if err != nil {
+			return wrapError(err, "Failed to update limit range")
+		}
+	}
+
+	if d.HasChange("network_isolation") {
+		if d.Get("network_isolation").(bool) {
+			err = createNamespacePolicyNetworkPolicy(conn, metadata)
+		} else {
+			log.Printf("[INFO] Deleting network policy: %#v", name)
+			err = networkPolicies(conn, namespace).Delete(name, &meta_v1.DeleteOptions{})
+		}
+		if err != nil {
+			return err
+		}
+	} else if d.Get("network_isolation").(bool) && d.HasChange("metadata") {
+		npOps := patchMetadata("metadata.0.", "/metadata/", d)
+		data, err := npOps.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("Failed to marshal update operations: %s", err)
+		}
+		log.Printf("[INFO] Updating network policy %q: %v", name, string(data))
+		_, err = networkPolicies(conn, namespace).Patch(name, pkgApi.JSONPatchType, data)
+		if err != nil {
+			return wrapError(err, "Failed to update network policy")
+		}
+	}
+
+	return resourceKubernetesNamespacePolicyRead(d, meta)
+}
+
+func resourceKubernetesNamespacePolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting network policy: %#v", name)
+	err = networkPolicies(conn, namespace).Delete(name, &meta_v1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] Deleting limit range: %#v", name)
+	err = conn.CoreV1().LimitRanges(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			return err
+		}
+	}
+
+	log.Printf("[INFO] Deleting resource quota: %#v", name)
+	err = conn.CoreV1().ResourceQuotas(namespace).Delete(name, &meta_v1.DeleteOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Namespace policy %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesNamespacePolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking resource quota %s", name)
+	_, err = conn.CoreV1().ResourceQuotas(namespace).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func createNamespacePolicyNetworkPolicy(conn *kubernetes.Clientset, metadata meta_v1.ObjectMeta) error {
+	np := expandNamespacePolicyNetworkPolicy(metadata)
+	log.Printf("[INFO] Creating new network policy: %#v", np)
+	out, err := networkPolicies(conn, metadata.Namespace).Create(&np)
+	if err != nil {
+		return wrapError(err, "Failed to create network policy")
+	}
+	log.Printf("[INFO] Submitted new network policy: %#v", out)
+	return nil
+}
+
+// networkPolicyClient talks to the extensions/v1beta1 network policy endpoints
+// which have no typed client in the vendored clientset.
+type networkPolicyClient struct {
+	client rest.Interface
+	ns     string
+}
+
+func networkPolicies(conn *kubernetes.Clientset, namespace string) *networkPolicyClient {
+	return &networkPolicyClient{
+		client: conn.ExtensionsV1beta1().RESTClient(),
+		ns:     namespace,
+	}
+}
+
+func (c *networkPolicyClient) Create(np *v1beta1.NetworkPolicy) (*v1beta1.NetworkPolicy, error) {
+	result := &v1beta1.NetworkPolicy{}
+	err := c.client.Post().
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Body(np).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *networkPolicyClient) Get(name string, options meta_v1.GetOptions) (*v1beta1.NetworkPolicy, error) {
+	result := &v1beta1.NetworkPolicy{}
+	err := c.client.Get().
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *networkPolicyClient) Patch(name string, pt pkgApi.PatchType, data []byte) (*v1beta1.NetworkPolicy, error) {
+	result := &v1beta1.NetworkPolicy{}
+	err := c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return result, err
+}
+
+func (c *networkPolicyClient) Delete(name string, options *meta_v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
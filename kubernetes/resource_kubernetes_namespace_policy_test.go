@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesNamespacePolicy_basic(t *testing.T) {
+	var conf api.ResourceQuota
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_namespace_policy.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesNamespacePolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesNamespacePolicyConfig_basic(name, "small", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesNamespacePolicyExists("kubernetes_namespace_policy.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "tier", "small"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "tier_limits.0.quota.pods", "10"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "tier_limits.0.default_limit.cpu", "500m"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "network_isolation", "false"),
+					testAccCheckResourceQuotaHard(&conf, map[string]string{"pods": "10", "cpu": "2"}),
+				),
+			},
+			{
+				Config: testAccKubernetesNamespacePolicyConfig_basic(name, "large", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesNamespacePolicyExists("kubernetes_namespace_policy.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "tier", "large"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "tier_limits.1.quota.pods", "50"),
+					resource.TestCheckResourceAttr("kubernetes_namespace_policy.test", "network_isolation", "true"),
+					testAccCheckResourceQuotaHard(&conf, map[string]string{"pods": "50", "cpu": "8"}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckResourceQuotaHard(quota *api.ResourceQuota, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		hard := flattenResourceList(quota.Spec.Hard)
+		if len(hard) != len(expected) {
+			return fmt.Errorf("Resource quota hard limits don't match.\nExpected: %q\nGiven: %q", expected, hard)
+		}
+		for k, v := range expected {
+			if hard[k] != v {
+				return fmt.Errorf("Resource quota hard limits don't match.\nExpected: %q\nGiven: %q", expected, hard)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesNamespacePolicyDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_namespace_policy" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := conn.CoreV1().ResourceQuotas(namespace).Get(name, meta_v1.GetOptions{}); err == nil {
+			return fmt.Errorf("Resource quota still exists: %s", rs.Primary.ID)
+		}
+		if _, err := conn.CoreV1().LimitRanges(namespace).Get(name, meta_v1.GetOptions{}); err == nil {
+			return fmt.Errorf("Limit range still exists: %s", rs.Primary.ID)
+		}
+		if _, err := networkPolicies(conn, namespace).Get(name, meta_v1.GetOptions{}); err == nil {
+			return fmt.Errorf("Network policy still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesNamespacePolicyExists(n string, obj *api.ResourceQuota) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.CoreV1().ResourceQuotas(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesNamespacePolicyConfig_basic(name, tier string, isolation bool) string {
+	return fmt.Sprintf(`
+resource "kubernetes_namespace" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+resource "kubernetes_namespace_policy" "test" {
+	metadata {
+		labels {
+			TestLabelOne = "one"
+		}
+		name = "%s"
+		namespace = "${kubernetes_namespace.test.metadata.0.name}"
+	}
+	tier = "%s"
+	tier_limits {
+		name = "small"
+		quota {
+			cpu = "2"
+			pods = "10"
+		}
+		default_limit {
+			cpu = "500m"
+		}
+	}
+	tier_limits {
+		name = "large"
+		quota {
+			cpu = "8"
+			pods = "50"
+		}
+		default_limit {
+			cpu = "1"
+		}
+		default_request {
+			cpu = "250m"
+		}
+	}
+	network_isolation = %t
+}
+`, name, name, tier, isolation)
+}
@@ -0,0 +1,207 @@
+package kubernetes
+
+import (
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func resourceKubernetesNode() *schema.Resource {
+	metadata := metadataSchema("node", false)
+	// Nodes are registered by the kubelet, we can only adopt existing ones
+	metadata.Elem.(*schema.Resource).Schema["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "Name of the node. Cannot be updated. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validateMetadataName("node"),
+	}
+
+	return &schema.Resource{
+		Create: resourceKubernetesNodeCreate,
+		Read:   resourceKubernetesNodeRead,
+		Exists: resourceKubernetesNodeExists,
+		Update: resourceKubernetesNodeUpdate,
+		Delete: resourceKubernetesNodeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"metadata": metadata,
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec defines the behavior of the node. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"taint": {
+							Type:        schema.TypeList,
+							Description: "Taints of the node. Taints which are not listed here (e.g. those set by cloud controllers) are left untouched.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:         schema.TypeString,
+										Description:  "The taint key to be applied to the node.",
+										Required:     true,
+										ValidateFunc: validateName,
+									},
+									"value": {
+										Type:        schema.TypeString,
+										Description: "The taint value corresponding to the taint key.",
+										Optional:    true,
+									},
+									"effect": {
+										Type:         schema.TypeString,
+										Description:  "The effect of the taint on pods that do not tolerate the taint. One of `NoSchedule`, `PreferNoSchedule` or `NoExecute`.",
+										Required:     true,
+										ValidateFunc: validateAttributeValueIsIn([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}),
+									},
+								},
+							},
+						},
+						"unschedulable": {
+							Type:        schema.TypeBool,
+							Description: "Unschedulable controls node schedulability of new pods (i.e. cordons the node). By default, node is schedulable. More info: http://releases.k8s.io/HEAD/docs/admin/node.md#manual-node-administration",
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesNodeCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Get("metadata.0.name").(string)
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec := expandNodeSpec(d.Get("spec").([]interface{}))
+
+	log.Printf("[INFO] Adopting node %s", name)
+	err := updateNode(conn, name, func(node *api.Node) error {
+		node.Labels = mergeStringMaps(node.Labels, nil, metadata.Labels)
+		node.Annotations = mergeStringMaps(node.Annotations, nil, metadata.Annotations)
+		node.Spec.Unschedulable = spec.Unschedulable
+		node.Spec.Taints = mergeNodeTaints(node.Spec.Taints, nil, spec.Taints)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(name)
+
+	return resourceKubernetesNodeRead(d, meta)
+}
+
+func resourceKubernetesNodeRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Id()
+	log.Printf("[INFO] Reading node %s", name)
+	node, err := conn.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received node: %#v", node)
+
+	// Labels, annotations and taints are shared with the kubelet
+	// and cloud controllers, only report those managed here
+	metadata := flattenMetadata(node.ObjectMeta)
+	metadata[0]["labels"] = filterStringMap(node.Labels, d.Get("metadata.0.labels").(map[string]interface{}))
+	metadata[0]["annotations"] = filterStringMap(node.Annotations, d.Get("metadata.0.annotations").(map[string]interface{}))
+	err = d.Set("metadata", metadata)
+	if err != nil {
+		return err
+	}
+
+	managed := expandNodeSpec(d.Get("spec").([]interface{}))
+	taints := make([]api.Taint, 0)
+	for _, t := range managed.Taints {
+		if taint := findNodeTaint(node.Spec.Taints, t.Key, t.Effect); taint != nil {
+			taints = append(taints, *taint)
+		}
+	}
+	err = d.Set("spec", flattenNodeSpec(node.Spec, taints))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesNodeUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Id()
+	oldMeta, newMeta := d.GetChange("metadata")
+	oldMetadata := expandMetadata(oldMeta.([]interface{}))
+	newMetadata := expandMetadata(newMeta.([]interface{}))
+	oldSpec, newSpec := d.GetChange("spec")
+	oldNodeSpec := expandNodeSpec(oldSpec.([]interface{}))
+	newNodeSpec := expandNodeSpec(newSpec.([]interface{}))
+
+	log.Printf("[INFO] Updating node %s", name)
+	err := updateNode(conn, name, func(node *api.Node) error {
+		node.Labels = mergeStringMaps(node.Labels, oldMetadata.Labels, newMetadata.Labels)
+		node.Annotations = mergeStringMaps(node.Annotations, oldMetadata.Annotations, newMetadata.Annotations)
+		node.Spec.Unschedulable = newNodeSpec.Unschedulable
+		node.Spec.Taints = mergeNodeTaints(node.Spec.Taints, oldNodeSpec.Taints, newNodeSpec.Taints)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceKubernetesNodeRead(d, meta)
+}
+
+func resourceKubernetesNodeDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Id()
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec := expandNodeSpec(d.Get("spec").([]interface{}))
+
+	// The node belongs to the kubelet, only undo what we've set
+	log.Printf("[INFO] Releasing node %s", name)
+	err := updateNode(conn, name, func(node *api.Node) error {
+		node.Labels = mergeStringMaps(node.Labels, metadata.Labels, nil)
+		node.Annotations = mergeStringMaps(node.Annotations, metadata.Annotations, nil)
+		if spec.Unschedulable {
+			node.Spec.Unschedulable = false
+		}
+		node.Spec.Taints = mergeNodeTaints(node.Spec.Taints, spec.Taints, nil)
+		return nil
+	})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesNodeExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	name := d.Id()
+	log.Printf("[INFO] Checking node %s", name)
+	_, err := conn.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
@@ -0,0 +1,294 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	policy "k8s.io/kubernetes/pkg/apis/policy/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+func resourceKubernetesNodeDrain() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesNodeDrainCreate,
+		Read:   resourceKubernetesNodeDrainRead,
+		Exists: resourceKubernetesNodeDrainExists,
+		Update: resourceKubernetesNodeDrainUpdate,
+		Delete: resourceKubernetesNodeDrainDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"node_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the node to drain.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"grace_period_seconds": {
+				Type:         schema.TypeInt,
+				Description:  "Period of time in seconds given to each pod to terminate gracefully. Defaults to the grace period of the pod.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDuration(0, 0),
+			},
+			"ignore_daemon_sets": {
+				Type:        schema.TypeBool,
+				Description: "Leave pods managed by daemon sets on the node, they would be recreated there right away. If false, the drain fails when such pods are found.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+			"skip_wait_for_delete_timeout": {
+				Type:         schema.TypeString,
+				Description:  "Stop waiting for pods which have been terminating for longer than this, e.g. on an unreachable node, as a Go duration like `5m` or a number of seconds. Pods are waited for until the create timeout by default.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDuration(time.Second, 0),
+			},
+			"uncordon_on_destroy": {
+				Type:        schema.TypeBool,
+				Description: "Make the node schedulable again when the resource is destroyed.",
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesNodeDrainCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName := d.Get("node_name").(string)
+
+	log.Printf("[INFO] Cordoning node %s", nodeName)
+	err := updateNode(conn, nodeName, func(node *api.Node) error {
+		node.Spec.Unschedulable = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(nodeName)
+
+	pods, err := podsToDrain(conn, nodeName, d.Get("ignore_daemon_sets").(bool))
+	if err != nil {
+		return err
+	}
+
+	var gracePeriod *int64
+	if v, ok := d.GetOk("grace_period_seconds"); ok {
+		seconds := int64(v.(int))
+		gracePeriod = &seconds
+	}
+
+	var skipWaitAfter time.Duration
+	if v, ok := d.GetOk("skip_wait_for_delete_timeout"); ok {
+		// Validated at plan time
+		skipWaitAfter, _ = parseDuration(v.(string))
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	for _, pod := range pods {
+		log.Printf("[INFO] Evicting pod %s/%s from node %s", pod.Namespace, pod.Name, nodeName)
+		err = evictPod(conn, pod.Namespace, pod.Name, gracePeriod, timeout)
+		if err != nil {
+			return wrapError(err, "Failed to evict pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
+
+	for _, pod := range pods {
+		pod := pod
+		err = waitWithEvents(conn, pod.ObjectMeta, "Pod", func() error {
+			return resource.Retry(timeout, waitForPodGoneFunc(conn, pod.Namespace, pod.Name, pod.UID, skipWaitAfter))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("[INFO] Node %s drained", nodeName)
+
+	return resourceKubernetesNodeDrainRead(d, meta)
+}
+
+func resourceKubernetesNodeDrainRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName := d.Id()
+	log.Printf("[INFO] Reading node %s", nodeName)
+	node, err := conn.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	// Someone uncordoned the node, drain it again on next apply
+	if !node.Spec.Unschedulable {
+		log.Printf("[INFO] Node %s is schedulable again", nodeName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("node_name", node.Name)
+
+	return nil
+}
+
+func resourceKubernetesNodeDrainUpdate(d *schema.ResourceData, meta interface{}) error {
+	// Only uncordon_on_destroy can change, it's used on destroy
+	return resourceKubernetesNodeDrainRead(d, meta)
+}
+
+func resourceKubernetesNodeDrainDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName := d.Id()
+	if d.Get("uncordon_on_destroy").(bool) {
+		log.Printf("[INFO] Uncordoning node %s", nodeName)
+		err := updateNode(conn, nodeName, func(node *api.Node) error {
+			node.Spec.Unschedulable = false
+			return nil
+		})
+		if err != nil {
+			if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+				return err
+			}
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesNodeDrainExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName := d.Id()
+	log.Printf("[INFO] Checking node %s", nodeName)
+	_, err := conn.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+// podsToDrain lists pods on the node which have to be evicted,
+// skipping mirror pods (managed by the kubelet itself) and finished pods
+func podsToDrain(conn *kubernetes.Clientset, nodeName string, ignoreDaemonSets bool) ([]api.Pod, error) {
+	fs := fields.Set(map[string]string{
+		"spec.nodeName": nodeName,
+	}).String()
+	list, err := conn.CoreV1().Pods("").List(metav1.ListOptions{FieldSelector: fs})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := make([]api.Pod, 0, len(list.Items))
+	for _, pod := range list.Items {
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+		if pod.Status.Phase == api.PodSucceeded || pod.Status.Phase == api.PodFailed {
+			continue
+		}
+		if isDaemonSetPod(pod) {
+			if ignoreDaemonSets {
+				continue
+			}
+			return nil, fmt.Errorf("Pod %s/%s on node %s is managed by a daemon set, set ignore_daemon_sets to drain the node anyway", pod.Namespace, pod.Name, nodeName)
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+func isDaemonSetPod(pod api.Pod) bool {
+	for _, ref := range pod.OwnerReferences {
+		if ref.Kind == "DaemonSet" && ref.Controller != nil && *ref.Controller {
+			return true
+		}
+	}
+	return false
+}
+
+// evictPod asks the API to evict the pod, retrying while the eviction
+// would violate a pod disruption budget (reported as 429 Too Many Requests)
+func evictPod(conn *kubernetes.Clientset, namespace, name string, gracePeriod *int64, timeout time.Duration) error {
+	eviction := &policy.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		DeleteOptions: &metav1.DeleteOptions{
+			GracePeriodSeconds: gracePeriod,
+		},
+	}
+	return waitWithEvents(conn, eviction.ObjectMeta, "Pod", func() error {
+		return resource.Retry(timeout, func() *resource.RetryError {
+			err := conn.CoreV1().Pods(namespace).Evict(eviction)
+			if err != nil {
+				if statusErr, ok := err.(*errors.StatusError); ok {
+					switch statusErr.ErrStatus.Code {
+					case 404:
+						return nil
+					case 429:
+						log.Printf("[DEBUG] Eviction of pod %s/%s blocked by disruption budget: %s", namespace, name, err)
+						return resource.RetryableError(err)
+					}
+				}
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+	})
+}
+
+// terminatingLongerThan tells whether the pod has been terminating for
+// longer than the given duration, never when it's 0
+func terminatingLongerThan(pod *api.Pod, d time.Duration) bool {
+	if d == 0 || pod.DeletionTimestamp == nil {
+		return false
+	}
+	return time.Since(pod.DeletionTimestamp.Time) > d
+}
+
+// waitForPodGoneFunc waits for the pod to be deleted, giving up on it once
+// it has been terminating for longer than skipAfter unless that's 0
+func waitForPodGoneFunc(conn *kubernetes.Clientset, namespace, name string, uid types.UID, skipAfter time.Duration) resource.RetryFunc {
+	return func() *resource.RetryError {
+		pod, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+		// Pods of stateful sets come back with the same name
+		if pod.UID != uid {
+			return nil
+		}
+		if terminatingLongerThan(pod, skipAfter) {
+			log.Printf("[WARN] Pod %s/%s has been terminating for longer than %s, not waiting for it", namespace, name, skipAfter)
+			return nil
+		}
+
+		e := fmt.Errorf("Pod %s/%s is still terminating", namespace, name)
+		log.Printf("[DEBUG] %s", e)
+		return resource.RetryableError(e)
+	}
+}
@@ -0,0 +1,135 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesNodeDrain_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesNodeDrainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesNodeDrainConfig_basic(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesNodeDrained("kubernetes_node_drain.test"),
+					resource.TestCheckResourceAttrSet("kubernetes_node_drain.test", "node_name"),
+					resource.TestCheckResourceAttr("kubernetes_node_drain.test", "grace_period_seconds", "5"),
+					resource.TestCheckResourceAttr("kubernetes_node_drain.test", "ignore_daemon_sets", "true"),
+					resource.TestCheckResourceAttr("kubernetes_node_drain.test", "skip_wait_for_delete_timeout", "2m"),
+					resource.TestCheckResourceAttr("kubernetes_node_drain.test", "uncordon_on_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestIsDaemonSetPod(t *testing.T) {
+	controller := true
+	cases := []struct {
+		Refs     []meta_v1.OwnerReference
+		Expected bool
+	}{
+		{nil, false},
+		{[]meta_v1.OwnerReference{{Kind: "ReplicaSet", Controller: &controller}}, false},
+		{[]meta_v1.OwnerReference{{Kind: "DaemonSet"}}, false},
+		{[]meta_v1.OwnerReference{{Kind: "DaemonSet", Controller: &controller}}, true},
+	}
+	for i, tc := range cases {
+		pod := api.Pod{ObjectMeta: meta_v1.ObjectMeta{OwnerReferences: tc.Refs}}
+		if got := isDaemonSetPod(pod); got != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, got)
+		}
+	}
+}
+
+func TestTerminatingLongerThan(t *testing.T) {
+	deleted := meta_v1.NewTime(time.Now().Add(-10 * time.Minute))
+	cases := []struct {
+		DeletionTimestamp *meta_v1.Time
+		Duration          time.Duration
+		Expected          bool
+	}{
+		{nil, 5 * time.Minute, false},
+		{&deleted, 0, false},
+		{&deleted, 5 * time.Minute, true},
+		{&deleted, 15 * time.Minute, false},
+	}
+	for i, tc := range cases {
+		pod := &api.Pod{ObjectMeta: meta_v1.ObjectMeta{DeletionTimestamp: tc.DeletionTimestamp}}
+		if got := terminatingLongerThan(pod, tc.Duration); got != tc.Expected {
+			t.Fatalf("%d: expected %t, got %t", i, tc.Expected, got)
+		}
+	}
+}
+
+func testAccCheckKubernetesNodeDrained(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		node, err := conn.CoreV1().Nodes().Get(rs.Primary.ID, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if !node.Spec.Unschedulable {
+			return fmt.Errorf("Node %s is not cordoned", node.Name)
+		}
+
+		pods, err := podsToDrain(conn, node.Name, true)
+		if err != nil {
+			return err
+		}
+		if len(pods) > 0 {
+			return fmt.Errorf("Node %s still runs %d pods", node.Name, len(pods))
+		}
+		return nil
+	}
+}
+
+func testAccCheckKubernetesNodeDrainDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_node_drain" {
+			continue
+		}
+
+		node, err := conn.CoreV1().Nodes().Get(rs.Primary.ID, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if node.Spec.Unschedulable {
+			return fmt.Errorf("Node %s is still cordoned", node.Name)
+		}
+	}
+
+	return nil
+}
+
+func testAccKubernetesNodeDrainConfig_basic() string {
+	return `
+data "kubernetes_objects" "nodes" {
+	kind = "Node"
+	limit = 1
+}
+
+resource "kubernetes_node_drain" "test" {
+	node_name = "${data.kubernetes_objects.nodes.objects.0.name}"
+	grace_period_seconds = 5
+	skip_wait_for_delete_timeout = "2m"
+}
+`
+}
@@ -0,0 +1,250 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func resourceKubernetesNodeTaint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesNodeTaintCreate,
+		Read:   resourceKubernetesNodeTaintRead,
+		Exists: resourceKubernetesNodeTaintExists,
+		Update: resourceKubernetesNodeTaintUpdate,
+		Delete: resourceKubernetesNodeTaintDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"node_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the node to taint.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Description:  "The taint key to be applied to the node.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateName,
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Description: "The taint value corresponding to the taint key.",
+				Optional:    true,
+			},
+			"effect": {
+				Type:         schema.TypeString,
+				Description:  "The effect of the taint on pods that do not tolerate the taint. One of `NoSchedule`, `PreferNoSchedule` or `NoExecute`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAttributeValueIsIn([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}),
+			},
+		},
+	}
+}
+
+func resourceKubernetesNodeTaintCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName := d.Get("node_name").(string)
+	taint := api.Taint{
+		Key:    d.Get("key").(string),
+		Value:  d.Get("value").(string),
+		Effect: api.TaintEffect(d.Get("effect").(string)),
+	}
+
+	log.Printf("[INFO] Adding taint %#v to node %s", taint, nodeName)
+	err := updateNodeTaints(conn, nodeName, func(taints []api.Taint) ([]api.Taint, error) {
+		if findNodeTaint(taints, taint.Key, taint.Effect) != nil {
+			return nil, fmt.Errorf("Node %s already has a %s taint with key %q", nodeName, taint.Effect, taint.Key)
+		}
+		return append(taints, taint), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(buildNodeTaintId(nodeName, taint.Key, taint.Effect))
+
+	return resourceKubernetesNodeTaintRead(d, meta)
+}
+
+func resourceKubernetesNodeTaintRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName, key, effect, err := nodeTaintIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading node %s", nodeName)
+	node, err := conn.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	taint := findNodeTaint(node.Spec.Taints, key, effect)
+	if taint == nil {
+		log.Printf("[INFO] Taint %s not found on node %s", key, nodeName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("node_name", nodeName)
+	d.Set("key", taint.Key)
+	d.Set("value", taint.Value)
+	d.Set("effect", string(taint.Effect))
+
+	return nil
+}
+
+func resourceKubernetesNodeTaintUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName, key, effect, err := nodeTaintIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("value") {
+		value := d.Get("value").(string)
+		log.Printf("[INFO] Updating value of taint %s on node %s to %q", key, nodeName, value)
+		err = updateNodeTaints(conn, nodeName, func(taints []api.Taint) ([]api.Taint, error) {
+			taint := findNodeTaint(taints, key, effect)
+			if taint == nil {
+				return nil, fmt.Errorf("Taint %s not found on node %s", key, nodeName)
+			}
+			taint.Value = value
+			return taints, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKubernetesNodeTaintRead(d, meta)
+}
+
+func resourceKubernetesNodeTaintDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName, key, effect, err := nodeTaintIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Removing taint %s from node %s", key, nodeName)
+	err = updateNodeTaints(conn, nodeName, func(taints []api.Taint) ([]api.Taint, error) {
+		out := make([]api.Taint, 0, len(taints))
+		for _, t := range taints {
+			if t.Key == key && t.Effect == effect {
+				continue
+			}
+			out = append(out, t)
+		}
+		return out, nil
+	})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); !ok || statusErr.ErrStatus.Code != 404 {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesNodeTaintExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	nodeName, key, effect, err := nodeTaintIdParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking node %s", nodeName)
+	node, err := conn.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return true, err
+	}
+	return findNodeTaint(node.Spec.Taints, key, effect) != nil, nil
+}
+
+func buildNodeTaintId(nodeName, key string, effect api.TaintEffect) string {
+	return nodeName + "/" + key + ":" + string(effect)
+}
+
+func nodeTaintIdParts(id string) (string, string, api.TaintEffect, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) == 2 {
+		i := strings.LastIndex(parts[1], ":")
+		if i > 0 {
+			return parts[0], parts[1][:i], api.TaintEffect(parts[1][i+1:]), nil
+		}
+	}
+	err := fmt.Errorf("Unexpected ID format (%q), expected %q.", id, "node/key:effect")
+	return "", "", "", err
+}
+
+func findNodeTaint(taints []api.Taint, key string, effect api.TaintEffect) *api.Taint {
+	for i := range taints {
+		if taints[i].Key == key && taints[i].Effect == effect {
+			return &taints[i]
+		}
+	}
+	return nil
+}
+
+// updateNodeTaints applies fn to the taints of a node
+func updateNodeTaints(conn *kubernetes.Clientset, nodeName string, fn func([]api.Taint) ([]api.Taint, error)) error {
+	return updateNode(conn, nodeName, func(node *api.Node) error {
+		taints, err := fn(node.Spec.Taints)
+		if err != nil {
+			return err
+		}
+		node.Spec.Taints = taints
+		return nil
+	})
+}
+
+// updateNode applies fn to a node, retrying on conflicts as other
+// controllers (e.g. the node lifecycle controller) update nodes too
+func updateNode(conn *kubernetes.Clientset, nodeName string, fn func(*api.Node) error) error {
+	return waitWithEvents(conn, metav1.ObjectMeta{Name: nodeName}, "Node", func() error {
+		return resource.Retry(1*time.Minute, func() *resource.RetryError {
+			node, err := conn.CoreV1().Nodes().Get(nodeName, metav1.GetOptions{})
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			err = fn(node)
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			_, err = conn.CoreV1().Nodes().Update(node)
+			if err != nil {
+				if errors.IsConflict(err) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+	})
+}
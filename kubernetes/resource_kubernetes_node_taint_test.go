@@ -0,0 +1,117 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesNodeTaint_basic(t *testing.T) {
+	key := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_node_taint.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesNodeTaintDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesNodeTaintConfig_basic(key, "one"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesNodeTaintExists("kubernetes_node_taint.test", "one"),
+					resource.TestCheckResourceAttr("kubernetes_node_taint.test", "key", key),
+					resource.TestCheckResourceAttr("kubernetes_node_taint.test", "value", "one"),
+					resource.TestCheckResourceAttr("kubernetes_node_taint.test", "effect", "PreferNoSchedule"),
+				),
+			},
+			{
+				Config: testAccKubernetesNodeTaintConfig_basic(key, "two"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesNodeTaintExists("kubernetes_node_taint.test", "two"),
+					resource.TestCheckResourceAttr("kubernetes_node_taint.test", "value", "two"),
+				),
+			},
+			{
+				ResourceName:      "kubernetes_node_taint.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesNodeTaintDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_node_taint" {
+			continue
+		}
+
+		nodeName, key, effect, err := nodeTaintIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		node, err := conn.CoreV1().Nodes().Get(nodeName, meta_v1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		if findNodeTaint(node.Spec.Taints, key, effect) != nil {
+			return fmt.Errorf("Node taint still exists: %s", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesNodeTaintExists(n, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		nodeName, key, effect, err := nodeTaintIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		node, err := conn.CoreV1().Nodes().Get(nodeName, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		taint := findNodeTaint(node.Spec.Taints, key, effect)
+		if taint == nil {
+			return fmt.Errorf("Taint %s not found on node %s", key, nodeName)
+		}
+		if taint.Value != value {
+			return fmt.Errorf("Expected taint %s to have value %q, got %q", key, value, taint.Value)
+		}
+		return nil
+	}
+}
+
+func testAccKubernetesNodeTaintConfig_basic(key, value string) string {
+	return fmt.Sprintf(`
+data "kubernetes_objects" "nodes" {
+	kind = "Node"
+	limit = 1
+}
+
+resource "kubernetes_node_taint" "test" {
+	node_name = "${data.kubernetes_objects.nodes.objects.0.name}"
+	key = "%s"
+	value = "%s"
+	effect = "PreferNoSchedule"
+}
+`, key, value)
+}
@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesNode_basic(t *testing.T) {
+	key := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_node.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesNodeDestroy(key),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesNodeConfig_basic(key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_node.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "metadata.0.labels."+key, "one"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "metadata.0.annotations.%", "0"),
+					resource.TestCheckResourceAttrSet("kubernetes_node.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "spec.0.unschedulable", "false"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "spec.0.taint.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "spec.0.taint.0.key", key),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "spec.0.taint.0.effect", "PreferNoSchedule"),
+				),
+			},
+			{
+				Config: testAccKubernetesNodeConfig_modified(key),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_node.test", "metadata.0.labels.%", "0"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "metadata.0.annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "metadata.0.annotations."+key, "two"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "spec.0.unschedulable", "true"),
+					resource.TestCheckResourceAttr("kubernetes_node.test", "spec.0.taint.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesNodeDestroy(key string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "kubernetes_node" {
+				continue
+			}
+
+			node, err := conn.CoreV1().Nodes().Get(rs.Primary.ID, meta_v1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if _, ok := node.Labels[key]; ok {
+				return fmt.Errorf("Label %s still exists on node %s", key, node.Name)
+			}
+			if _, ok := node.Annotations[key]; ok {
+				return fmt.Errorf("Annotation %s still exists on node %s", key, node.Name)
+			}
+			if node.Spec.Unschedulable {
+				return fmt.Errorf("Node %s is still cordoned", node.Name)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccKubernetesNodeConfig_basic(key string) string {
+	return fmt.Sprintf(`
+data "kubernetes_objects" "nodes" {
+	kind = "Node"
+	limit = 1
+}
+
+resource "kubernetes_node" "test" {
+	metadata {
+		name = "${data.kubernetes_objects.nodes.objects.0.name}"
+		labels {
+			"%s" = "one"
+		}
+	}
+	spec {
+		taint {
+			key = "%s"
+			effect = "PreferNoSchedule"
+		}
+	}
+}
+`, key, key)
+}
+
+func testAccKubernetesNodeConfig_modified(key string) string {
+	return fmt.Sprintf(`
+data "kubernetes_objects" "nodes" {
+	kind = "Node"
+	limit = 1
+}
+
+resource "kubernetes_node" "test" {
+	metadata {
+		name = "${data.kubernetes_objects.nodes.objects.0.name}"
+		annotations {
+			"%s" = "two"
+		}
+	}
+	spec {
+		unschedulable = true
+	}
+}
+`, key)
+}
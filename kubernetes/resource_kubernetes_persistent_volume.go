@@ -48,10 +48,11 @@ func resourceKubernetesPersistentVolume() *schema.Resource {
 							ValidateFunc: validateResourceList,
 						},
 						"persistent_volume_reclaim_policy": {
-							Type:        schema.TypeString,
-							Description: "What happens to a persistent volume when released from its claim. Valid options are Retain (default) and Recycle. Recycling must be supported by the volume plugin underlying this persistent volume. More info: http://kubernetes.io/docs/user-guide/persistent-volumes#recycling-policy",
-							Optional:    true,
-							Default:     "Retain",
+							Type:         schema.TypeString,
+							Description:  "What happens to a persistent volume when released from its claim. Valid options are Retain (default), Delete and Recycle. Recycling must be supported by the volume plugin underlying this persistent volume. Can be updated without recreating the volume. More info: http://kubernetes.io/docs/user-guide/persistent-volumes#recycling-policy",
+							Optional:     true,
+							Default:      "Retain",
+							ValidateFunc: validateAttributeValueIsIn([]string{"Delete", "Recycle", "Retain"}),
 						},
 						"persistent_volume_source": {
 							Type:        schema.TypeList,
@@ -103,7 +104,10 @@ func resourceKubernetesPersistentVolumeCreate(d *schema.ResourceData, meta inter
 			return out, statusPhase, nil
 		},
 	}
-	_, err = stateConf.WaitForState()
+	err = waitWithEvents(conn, out.ObjectMeta, "PersistentVolume", func() error {
+		_, err := stateConf.WaitForState()
+		return err
+	})
 	if err != nil {
 		return err
 	}
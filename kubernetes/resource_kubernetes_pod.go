@@ -25,7 +25,8 @@ func resourceKubernetesPod() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("pod", true),
+			"metadata":       namespacedMetadataSchema("pod", true),
+			"mesh_injection": meshInjectionSchema(false),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec of the pod owned by the cluster",
@@ -46,16 +47,19 @@ func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error
 	if err != nil {
 		return err
 	}
+	expandExplicitZeroValues(d, "spec.0.", &spec)
 
 	spec.AutomountServiceAccountToken = ptrToBool(false)
+	metadata.Annotations = expandMeshInjection(d.Get("mesh_injection").([]interface{}), metadata.Annotations)
 
-	pod := api.Pod{
+	pod := podWithNewerSpec{
 		ObjectMeta: metadata,
 		Spec:       spec,
 	}
 
 	log.Printf("[INFO] Creating new pod: %#v", pod)
-	out, err := conn.CoreV1().Pods(metadata.Namespace).Create(&pod)
+	var out podWithNewerSpec
+	err = objectKinds["Pod"].create(conn, metadata.Namespace, &pod, &out)
 
 	if err != nil {
 		return err
@@ -80,13 +84,12 @@ func resourceKubernetesPodCreate(d *schema.ResourceData, meta interface{}) error
 			return out, statusPhase, nil
 		},
 	}
-	_, err = stateConf.WaitForState()
+	err = waitWithEvents(conn, out.ObjectMeta, "Pod", func() error {
+		_, err := stateConf.WaitForState()
+		return err
+	})
 	if err != nil {
-		lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Pod", 3)
-		if wErr != nil {
-			return wErr
-		}
-		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		return err
 	}
 	log.Printf("[INFO] Pod %s created", out.Name)
 
@@ -135,13 +138,22 @@ func resourceKubernetesPodRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Printf("[INFO] Reading pod %s", name)
-	pod, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+	var pod podWithNewerSpec
+	err = objectKinds["Pod"].get(conn, namespace, name, &pod)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
 	}
 	log.Printf("[INFO] Received pod: %#v", pod)
 
+	// Only take over the injection annotation when it's managed via mesh_injection
+	if len(d.Get("mesh_injection").([]interface{})) > 0 {
+		err = d.Set("mesh_injection", flattenMeshInjection(pod.ObjectMeta.Annotations))
+		if err != nil {
+			return err
+		}
+	}
+
 	err = d.Set("metadata", flattenMetadata(pod.ObjectMeta))
 	if err != nil {
 		return err
@@ -174,18 +186,21 @@ func resourceKubernetesPodDelete(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
-		out, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
-		if err != nil {
-			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
-				return nil
+	metadata := metav1.ObjectMeta{Namespace: namespace, Name: name}
+	err = waitWithEvents(conn, metadata, "Pod", func() error {
+		return resource.Retry(1*time.Minute, func() *resource.RetryError {
+			out, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+			if err != nil {
+				if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+					return nil
+				}
+				return resource.NonRetryableError(err)
 			}
-			return resource.NonRetryableError(err)
-		}
 
-		log.Printf("[DEBUG] Current state of pod: %#v", out.Status.Phase)
-		e := fmt.Errorf("Pod %s still exists (%s)", name, out.Status.Phase)
-		return resource.RetryableError(e)
+			log.Printf("[DEBUG] Current state of pod: %#v", out.Status.Phase)
+			e := fmt.Errorf("Pod %s still exists (%s)", name, out.Status.Phase)
+			return resource.RetryableError(e)
+		})
 	})
 	if err != nil {
 		return err
@@ -215,3 +230,12 @@ func resourceKubernetesPodExists(d *schema.ResourceData, meta interface{}) (bool
 	}
 	return true, err
 }
+
+// podWithNewerSpec is a pod including spec fields
+// which the vendored API types predate
+type podWithNewerSpec struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              podSpec       `json:"spec,omitempty"`
+	Status            api.PodStatus `json:"status,omitempty"`
+}
@@ -0,0 +1,219 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func resourceKubernetesPodTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesPodTemplateCreate,
+		Read:   resourceKubernetesPodTemplateRead,
+		Exists: resourceKubernetesPodTemplateExists,
+		Update: resourceKubernetesPodTemplateUpdate,
+		Delete: resourceKubernetesPodTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata": namespacedMetadataSchema("pod template", true),
+			"template": {
+				Type:        schema.TypeList,
+				Description: "Template defines the pods that will be created from this pod template. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"metadata": {
+							Type:        schema.TypeList,
+							Description: "Metadata of the pods created from this template.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"annotations": {
+										Type:         schema.TypeMap,
+										Description:  "An unstructured key value map stored with the pods that may be used to store arbitrary metadata. More info: http://kubernetes.io/docs/user-guide/annotations",
+										Optional:     true,
+										ValidateFunc: validateAnnotations,
+									},
+									"labels": {
+										Type:         schema.TypeMap,
+										Description:  "Map of string keys and values that can be used to organize and categorize (scope and select) the pods. May match selectors of replication controllers and services. More info: http://kubernetes.io/docs/user-guide/labels",
+										Optional:     true,
+										ValidateFunc: validateLabels,
+									},
+								},
+							},
+						},
+						"spec": {
+							Type:        schema.TypeList,
+							Description: "Specification of the desired behavior of the pods. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: podSpecFields(true),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesPodTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	template, err := expandPodTemplateSpec(d.Get("template").([]interface{}))
+	if err != nil {
+		return err
+	}
+	expandExplicitZeroValues(d, "template.0.spec.0.", &template.Spec)
+
+	pt := podTemplateWithNewerSpec{
+		ObjectMeta: metadata,
+		Template:   template,
+	}
+
+	log.Printf("[INFO] Creating new pod template: %#v", pt)
+	var out podTemplateWithNewerSpec
+	err = podTemplateKind.create(conn, metadata.Namespace, &pt, &out)
+	if err != nil {
+		return wrapError(err, "Failed to create pod template")
+	}
+	log.Printf("[INFO] Submitted new pod template: %#v", out)
+
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesPodTemplateRead(d, meta)
+}
+
+func resourceKubernetesPodTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading pod template %s", name)
+	var pt podTemplateWithNewerSpec
+	err = podTemplateKind.get(conn, namespace, name, &pt)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received pod template: %#v", pt)
+
+	err = d.Set("metadata", flattenMetadata(pt.ObjectMeta))
+	if err != nil {
+		return err
+	}
+
+	template, err := flattenPodTemplateSpec(pt.Template)
+	if err != nil {
+		return err
+	}
+	err = d.Set("template", template)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesPodTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+	if d.HasChange("template") {
+		template, err := expandPodTemplateSpec(d.Get("template").([]interface{}))
+		if err != nil {
+			return err
+		}
+		expandExplicitZeroValues(d, "template.0.spec.0.", &template.Spec)
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/template",
+			Value: template,
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating pod template %q: %v", name, string(data))
+	out, err := conn.CoreV1().PodTemplates(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	if err != nil {
+		return wrapError(err, "Failed to update pod template")
+	}
+	log.Printf("[INFO] Submitted updated pod template: %#v", out)
+	d.SetId(buildId(out.ObjectMeta))
+
+	return resourceKubernetesPodTemplateRead(d, meta)
+}
+
+func resourceKubernetesPodTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting pod template: %#v", name)
+	err = conn.CoreV1().PodTemplates(namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Pod template %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesPodTemplateExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking pod template %s", name)
+	_, err = conn.CoreV1().PodTemplates(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+// podTemplateKind reaches pod templates through raw REST requests.
+// It's not among objectKinds as pod templates have no status and
+// aren't labeled or annotated on their own.
+var podTemplateKind = objectKind{[]string{"v1"}, "podtemplates", true}
+
+// podTemplateWithNewerSpec is a pod template including spec fields
+// which the vendored API types predate
+type podTemplateWithNewerSpec struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Template          podTemplateSpec `json:"template,omitempty"`
+}
@@ -0,0 +1,189 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesPodTemplate_basic(t *testing.T) {
+	var conf api.PodTemplate
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_pod_template.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesPodTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodTemplateConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodTemplateExists("kubernetes_pod_template.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.annotations.TestAnnotationOne", "one"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{"TestAnnotationOne": "one"}),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.labels.TestLabelOne", "one"),
+					testAccCheckMetaLabels(&conf.ObjectMeta, map[string]string{"TestLabelOne": "one"}),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("kubernetes_pod_template.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.metadata.0.labels.app", "web"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.spec.0.container.0.image", "nginx:1.7.8"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.spec.0.container.0.name", "tf-acc-test"),
+				),
+			},
+			{
+				Config: testAccKubernetesPodTemplateConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodTemplateExists("kubernetes_pod_template.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.annotations.%", "0"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{}),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.labels.TestLabelOne", "one"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.metadata.0.labels.%", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.metadata.0.labels.app", "web"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.metadata.0.labels.tier", "frontend"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.spec.0.container.0.image", "nginx:1.7.9"),
+					resource.TestCheckResourceAttr("kubernetes_pod_template.test", "template.0.spec.0.container.0.name", "tf-acc-test"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPodTemplate_importBasic(t *testing.T) {
+	resourceName := "kubernetes_pod_template.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodTemplateConfig_basic(name),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPodTemplateDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_pod_template" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.CoreV1().PodTemplates(namespace).Get(name, meta_v1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Pod template still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesPodTemplateExists(n string, obj *api.PodTemplate) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.CoreV1().PodTemplates(namespace).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesPodTemplateConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod_template" "test" {
+  metadata {
+    annotations {
+      TestAnnotationOne = "one"
+    }
+    labels {
+      TestLabelOne = "one"
+    }
+    name = "%s"
+  }
+  template {
+    metadata {
+      labels {
+        app = "web"
+      }
+    }
+    spec {
+      container {
+        image = "nginx:1.7.8"
+        name  = "tf-acc-test"
+      }
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesPodTemplateConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod_template" "test" {
+  metadata {
+    labels {
+      TestLabelOne = "one"
+    }
+    name = "%s"
+  }
+  template {
+    metadata {
+      labels {
+        app  = "web"
+        tier = "frontend"
+      }
+    }
+    spec {
+      container {
+        image = "nginx:1.7.9"
+        name  = "tf-acc-test"
+      }
+    }
+  }
+}
+`, name)
+}
@@ -216,6 +216,58 @@ func TestAccKubernetesPod_with_container_liveness_probe_using_http_get(t *testin
 	})
 }
 
+func TestAccKubernetesPod_with_container_startup_probe(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "gcr.io/google_containers/liveness"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithStartupProbe(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.startup_probe.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.startup_probe.0.http_get.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.startup_probe.0.http_get.0.path", "/healthz"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.startup_probe.0.http_get.0.port", "8080"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.startup_probe.0.failure_threshold", "30"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.liveness_probe.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_container_liveness_probe_using_grpc(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "registry.k8s.io/etcd:3.5.1-0"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithLivenessProbeUsingGRPC(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.liveness_probe.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.liveness_probe.0.grpc.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.liveness_probe.0.grpc.0.port", "2379"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.liveness_probe.0.grpc.0.service", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPod_with_container_liveness_probe_using_tcp(t *testing.T) {
 	var conf api.Pod
 
@@ -272,6 +324,33 @@ func TestAccKubernetesPod_with_container_lifecycle(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPod_with_container_lifecycle_sleep(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "gcr.io/google_containers/liveness"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithLifeCycleSleep(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.lifecycle.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.lifecycle.0.post_start.#", "0"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.lifecycle.0.pre_stop.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.lifecycle.0.pre_stop.0.exec.#", "0"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.lifecycle.0.pre_stop.0.sleep.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.lifecycle.0.pre_stop.0.sleep.0.seconds", "5"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPod_with_container_security_context(t *testing.T) {
 	var conf api.Pod
 
@@ -390,6 +469,28 @@ func TestAccKubernetesPod_with_resource_requirements(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesPod_with_ephemeral_storage_requirements(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigWithEphemeralStorageRequirements(podName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.requests.0.ephemeral_storage", "1Gi"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.resources.0.limits.0.ephemeral_storage", "2Gi"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesPod_with_empty_dir_volume(t *testing.T) {
 	var conf api.Pod
 
@@ -467,108 +568,441 @@ func TestAccKubernetesPod_gke_with_nodeSelector(t *testing.T) {
 	})
 }
 
-func testAccCheckKubernetesPodDestroy(s *terraform.State) error {
-	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+func TestAccKubernetesPod_with_os(t *testing.T) {
+	var conf api.Pod
 
-	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "kubernetes_pod" {
-			continue
-		}
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
 
-		namespace, name, err := idParts(rs.Primary.ID)
-		if err != nil {
-			return err
-		}
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigOs(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.os", "linux"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.node_selector.kubernetes.io/os", "linux"),
+					func(s *terraform.State) error {
+						if conf.Spec.NodeSelector["kubernetes.io/os"] != "linux" {
+							return fmt.Errorf("Expected node selector for linux, got %#v", conf.Spec.NodeSelector)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
 
-		resp, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
-		if err == nil {
-			if resp.Namespace == namespace && resp.Name == name {
-				return fmt.Errorf("Pod still exists: %s: %#v", rs.Primary.ID, resp.Status.Phase)
-			}
-		}
-	}
+func TestAccKubernetesPod_with_init_container(t *testing.T) {
+	var conf api.Pod
 
-	return nil
-}
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
 
-func testAccCheckKubernetesPodExists(n string, obj *api.Pod) resource.TestCheckFunc {
-	return func(s *terraform.State) error {
-		rs, ok := s.RootModule().Resources[n]
-		if !ok {
-			return fmt.Errorf("Not found: %s", n)
-		}
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigInitContainer(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.name", "init"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.image", "busybox"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.volume_mount.0.mount_path", "/usr/share/nginx/html"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.image", imageName),
+				),
+			},
+		},
+	})
+}
 
-		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+func TestAccKubernetesPod_with_sidecar(t *testing.T) {
+	var conf api.Pod
 
-		namespace, name, err := idParts(rs.Primary.ID)
-		if err != nil {
-			return err
-		}
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
 
-		out, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-		*obj = *out
-		return nil
-	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigSidecar(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.name", "log-tailer"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.restart_policy", "Always"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.init_container.0.startup_probe.#", "1"),
+				),
+			},
+		},
+	})
 }
 
-func testAccKubernetesPodConfigBasic(secretName, configMapName, podName, imageName string) string {
-	return fmt.Sprintf(`
+func TestAccKubernetesPod_with_ephemeral_volume(t *testing.T) {
+	var conf api.Pod
 
-resource "kubernetes_secret" "test" {
-  metadata {
-    name = "%s"
-  }
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
 
-  data {
-    one = "first"
-  }
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigEphemeralVolume(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.ephemeral.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.ephemeral.0.volume_claim_template.0.metadata.0.labels.app", podName),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.ephemeral.0.volume_claim_template.0.spec.0.resources.0.requests.storage", "1Gi"),
+				),
+			},
+		},
+	})
 }
 
-resource "kubernetes_config_map" "test" {
-  metadata {
-    name = "%s"
-  }
+func TestAccKubernetesPod_with_seccomp_profile(t *testing.T) {
+	var conf api.Pod
 
-  data {
-    one = "ONE"
-  }
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigSeccompProfile(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.security_context.0.seccomp_profile.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.security_context.0.seccomp_profile.0.type", "RuntimeDefault"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.security_context.0.seccomp_profile.0.type", "Unconfined"),
+				),
+			},
+		},
+	})
 }
 
-resource "kubernetes_pod" "test" {
-  metadata {
-    labels {
-      app = "pod_label"
-    }
+func TestAccKubernetesPod_with_topology_spread_constraint(t *testing.T) {
+	var conf api.Pod
 
-    name = "%s"
-  }
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
 
-  spec {
-    container {
-      image = "%s"
-      name  = "containername"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigTopologySpreadConstraint(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.topology_spread_constraint.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.topology_spread_constraint.0.max_skew", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.topology_spread_constraint.0.topology_key", "kubernetes.io/hostname"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.topology_spread_constraint.0.when_unsatisfiable", "ScheduleAnyway"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.topology_spread_constraint.0.label_selector.0.match_labels.app", podName),
+				),
+			},
+		},
+	})
+}
 
-      env = [{
-        name = "EXPORTED_VARIBALE_FROM_SECRET"
+func TestAccKubernetesPod_with_affinity(t *testing.T) {
+	var conf api.Pod
 
-        value_from {
-          secret_key_ref {
-            name = "${kubernetes_secret.test.metadata.0.name}"
-            key  = "one"
-          }
-        }
-      },
-        {
-          name = "EXPORTED_VARIBALE_FROM_CONFIG_MAP"
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
 
-          value_from {
-            config_map_key_ref {
-              name = "${kubernetes_config_map.test.metadata.0.name}"
-              key  = "one"
-            }
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigAffinity(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.affinity.0.node_affinity.0.required_during_scheduling_ignored_during_execution.0.node_selector_term.0.match_expressions.0.key", "kubernetes.io/os"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.affinity.0.node_affinity.0.preferred_during_scheduling_ignored_during_execution.0.weight", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.0.weight", "100"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.0.pod_affinity_term.0.topology_key", "kubernetes.io/hostname"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.affinity.0.pod_anti_affinity.0.preferred_during_scheduling_ignored_during_execution.0.pod_affinity_term.0.label_selector.0.match_labels.app", podName),
+					func(s *terraform.State) error {
+						if conf.Spec.Affinity == nil || conf.Spec.Affinity.PodAntiAffinity == nil {
+							return fmt.Errorf("Expected pod anti-affinity, got %#v", conf.Spec.Affinity)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_tolerations(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigTolerations(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.0.key", "dedicated"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.0.value", "tf-acc-test"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.0.effect", "NoSchedule"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.1.operator", "Exists"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.toleration.1.toleration_seconds", "60"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_env_from(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	secretName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	configMapName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigEnvFrom(secretName, configMapName, podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.#", "2"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.0.config_map_ref.0.name", configMapName),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.0.prefix", "CONFIG_"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.1.secret_ref.0.name", secretName),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env_from.1.secret_ref.0.optional", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_projected_volume(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	secretName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	configMapName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigProjectedVolume(secretName, configMapName, podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.#", "4"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.0.secret.0.name", secretName),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.0.secret.0.items.0.path", "secret/one"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.1.config_map.0.name", configMapName),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.2.downward_api.0.items.0.path", "labels"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.3.service_account_token.0.audience", "vault"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.3.service_account_token.0.expiration_seconds", "7200"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.projected.0.sources.3.service_account_token.0.path", "token"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_downward_api(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigDownwardAPI(podName, imageName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.0.value_from.0.field_ref.0.field_path", "metadata.namespace"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.1.value_from.0.resource_field_ref.0.resource", "limits.memory"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.container.0.env.1.value_from.0.resource_field_ref.0.divisor", "1Mi"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.downward_api.0.default_mode", "420"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.downward_api.0.items.0.field_ref.0.field_path", "metadata.labels"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "spec.0.volume.0.downward_api.0.items.1.resource_field_ref.0.divisor", "1m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesPod_with_mesh_injection(t *testing.T) {
+	var conf api.Pod
+
+	podName := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+	imageName := "nginx:1.7.9"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesPodDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesPodConfigMeshInjection(podName, imageName, "istio", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "metadata.0.annotations.%", "0"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{"sidecar.istio.io/inject": "false"}),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "mesh_injection.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "mesh_injection.0.provider", "istio"),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "mesh_injection.0.enabled", "false"),
+				),
+			},
+			{
+				Config: testAccKubernetesPodConfigMeshInjection(podName, imageName, "linkerd", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesPodExists("kubernetes_pod.test", &conf),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{"linkerd.io/inject": "disabled"}),
+					resource.TestCheckResourceAttr("kubernetes_pod.test", "mesh_injection.0.provider", "linkerd"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesPodDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_pod" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		resp, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Pod still exists: %s: %#v", rs.Primary.ID, resp.Status.Phase)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesPodExists(n string, obj *api.Pod) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		out, err := conn.CoreV1().Pods(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		*obj = *out
+		return nil
+	}
+}
+
+func testAccKubernetesPodConfigBasic(secretName, configMapName, podName, imageName string) string {
+	return fmt.Sprintf(`
+
+resource "kubernetes_secret" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data {
+    one = "first"
+  }
+}
+
+resource "kubernetes_config_map" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data {
+    one = "ONE"
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      env = [{
+        name = "EXPORTED_VARIBALE_FROM_SECRET"
+
+        value_from {
+          secret_key_ref {
+            name = "${kubernetes_secret.test.metadata.0.name}"
+            key  = "one"
+          }
+        }
+      },
+        {
+          name = "EXPORTED_VARIBALE_FROM_CONFIG_MAP"
+
+          value_from {
+            config_map_key_ref {
+              name = "${kubernetes_config_map.test.metadata.0.name}"
+              key  = "one"
+            }
           }
         },
       ]
@@ -667,103 +1101,645 @@ resource "kubernetes_pod" "test" {
             value = "Awesome"
           }
         }
-        initial_delay_seconds = 3
-        period_seconds        = 3
+        initial_delay_seconds = 3
+        period_seconds        = 3
+      }
+    }
+  }
+}
+	`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithStartupProbe(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      args  = ["/server"]
+
+      startup_probe {
+        http_get {
+          path = "/healthz"
+          port = 8080
+        }
+        failure_threshold = 30
+        period_seconds    = 1
+      }
+
+      liveness_probe {
+        http_get {
+          path = "/healthz"
+          port = 8080
+        }
+        period_seconds = 3
+      }
+    }
+  }
+}
+	`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithLivenessProbeUsingGRPC(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image   = "%s"
+      name    = "containername"
+      command = ["/usr/local/bin/etcd", "--data-dir", "/var/lib/etcd", "--listen-client-urls", "http://0.0.0.0:2379", "--advertise-client-urls", "http://127.0.0.1:2379", "--log-level", "debug"]
+
+      liveness_probe {
+        grpc {
+          port = 2379
+        }
+        initial_delay_seconds = 10
+      }
+    }
+  }
+}
+	`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithLivenessProbeUsingTCP(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      args  = ["/server"]
+
+      liveness_probe {
+        tcp_socket {
+          port = 8080
+        }
+
+        initial_delay_seconds = 3
+        period_seconds        = 3
+      }
+    }
+  }
+}
+	`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithLifeCycle(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      args  = ["/server"]
+
+      lifecycle {
+        post_start {
+          exec {
+            command = ["ls", "-al"]
+          }
+        }
+        pre_stop {
+          exec {
+            command = ["date"]
+          }
+        }
+      }
+    }
+  }
+}
+
+	`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithLifeCycleSleep(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      args  = ["/server"]
+
+      lifecycle {
+        pre_stop {
+          sleep {
+            seconds = 5
+          }
+        }
+      }
+    }
+  }
+}
+	`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithContainerSecurityContext(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+
+      security_context {
+        privileged  = true
+        run_as_user = 1
+        se_linux_options {
+          level = "s0:c123,c456"
+        }
+        capabilities {
+          add = ["NET_ADMIN", "SYS_TIME"]
+        }
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithVolumeMounts(secretName, podName, imageName string) string {
+	return fmt.Sprintf(`
+
+resource "kubernetes_secret" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data {
+    one = "first"
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      volume_mount {
+        mount_path = "/tmp/my_path"
+        name       = "db"
+      }
+    }
+    volume {
+      name = "db"
+      secret {
+        secret_name = "${kubernetes_secret.test.metadata.0.name}"
+      }
+    }
+  }
+}
+	`, secretName, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithSecretItemsVolume(secretName, podName, imageName string) string {
+	return fmt.Sprintf(`
+
+resource "kubernetes_secret" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data {
+    one = "first"
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      volume_mount {
+        mount_path = "/tmp/my_path"
+        name       = "db"
+      }
+    }
+    volume {
+      name = "db"
+      secret {
+        secret_name = "${kubernetes_secret.test.metadata.0.name}"
+        items {
+          key = "one"
+          path = "path/to/one"
+        }
+      }
+    }
+  }
+}
+	`, secretName, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithConfigMapVolume(secretName, podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_config_map" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data {
+    one = "first"
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      volume_mount {
+        mount_path = "/tmp/my_path"
+        name       = "cfg"
+      }
+    }
+    volume {
+      name = "cfg"
+      config_map {
+        name = "${kubernetes_config_map.test.metadata.0.name}"
+        default_mode = 0777
+      }
+    }
+  }
+}
+	`, secretName, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithResourceRequirements(podName, imageName string) string {
+	return fmt.Sprintf(`
+
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+		
+			resources{
+				limits{
+					cpu = "0.5"
+					memory = "512Mi"
+				}
+				requests{
+					cpu = "250m"
+				  memory = "50Mi"
+				}
+			}
+			
+    }
+  }
+}
+	`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigWithEphemeralStorageRequirements(podName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "nginx:1.7.9"
+      name  = "containername"
+
+      resources {
+        limits {
+          ephemeral_storage = "2Gi"
+        }
+        requests {
+          ephemeral_storage = "1Gi"
+        }
+      }
+    }
+  }
+}
+`, podName)
+}
+
+func testAccKubernetesPodConfigWithEmptyDirVolumes(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    labels {
+      app = "pod_label"
+    }
+
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+      volume_mount {
+        mount_path =  "/cache"
+        name =  "cache-volume"
+      }
+    }
+    volume {
+      name = "cache-volume"
+      empty_dir = {
+        medium = "Memory"
+      }
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigNodeSelector(podName, imageName, region string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+    }
+    node_selector {
+      "failure-domain.beta.kubernetes.io/region" = "%s"
+    }
+  }
+}
+`, podName, imageName, region)
+}
+
+func testAccKubernetesPodConfigOs(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    container {
+      image = "%s"
+      name  = "containername"
+    }
+    os = "linux"
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigInitContainer(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    init_container {
+      image   = "busybox"
+      name    = "init"
+      command = ["sh", "-c", "echo Hello > /usr/share/nginx/html/index.html"]
+
+      volume_mount {
+        name       = "html"
+        mount_path = "/usr/share/nginx/html"
+      }
+    }
+    container {
+      image = "%s"
+      name  = "containername"
+
+      volume_mount {
+        name       = "html"
+        mount_path = "/usr/share/nginx/html"
+      }
+    }
+    volume {
+      name = "html"
+      empty_dir {}
+    }
+  }
+}
+`, podName, imageName)
+}
+
+func testAccKubernetesPodConfigSidecar(podName, imageName string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_pod" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    init_container {
+      image          = "busybox"
+      name           = "log-tailer"
+      restart_policy = "Always"
+      command        = ["sh", "-c", "touch /var/log/nginx/access.log && tail -F /var/log/nginx/access.log"]
+
+      startup_probe {
+        exec {
+          command = ["test", "-f", "/var/log/nginx/access.log"]
+        }
+        period_seconds = 1
+      }
+
+      volume_mount {
+        name       = "logs"
+        mount_path = "/var/log/nginx"
+      }
+    }
+    container {
+      image = "%s"
+      name  = "containername"
+
+      volume_mount {
+        name       = "logs"
+        mount_path = "/var/log/nginx"
       }
     }
+    volume {
+      name = "logs"
+      empty_dir {}
+    }
   }
 }
-	`, podName, imageName)
+`, podName, imageName)
 }
 
-func testAccKubernetesPodConfigWithLivenessProbeUsingTCP(podName, imageName string) string {
+func testAccKubernetesPodConfigAffinity(podName, imageName string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
   metadata {
+    name = "%s"
     labels {
-      app = "pod_label"
+      app = "%s"
     }
-
-    name = "%s"
   }
   spec {
+    affinity {
+      node_affinity {
+        required_during_scheduling_ignored_during_execution {
+          node_selector_term {
+            match_expressions {
+              key      = "kubernetes.io/os"
+              operator = "In"
+              values   = ["linux"]
+            }
+          }
+        }
+        preferred_during_scheduling_ignored_during_execution {
+          weight = 1
+          preference {
+            match_expressions {
+              key      = "disktype"
+              operator = "Exists"
+            }
+          }
+        }
+      }
+      pod_anti_affinity {
+        preferred_during_scheduling_ignored_during_execution {
+          weight = 100
+          pod_affinity_term {
+            label_selector {
+              match_labels {
+                app = "%s"
+              }
+            }
+            topology_key = "kubernetes.io/hostname"
+          }
+        }
+      }
+    }
     container {
       image = "%s"
       name  = "containername"
-      args  = ["/server"]
-
-      liveness_probe {
-        tcp_socket {
-          port = 8080
-        }
-
-        initial_delay_seconds = 3
-        period_seconds        = 3
-      }
     }
   }
 }
-	`, podName, imageName)
+`, podName, podName, podName, imageName)
 }
 
-func testAccKubernetesPodConfigWithLifeCycle(podName, imageName string) string {
+func testAccKubernetesPodConfigEphemeralVolume(podName, imageName string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
   metadata {
-    labels {
-      app = "pod_label"
-    }
-
     name = "%s"
   }
   spec {
     container {
       image = "%s"
       name  = "containername"
-      args  = ["/server"]
-
-      lifecycle {
-        post_start {
-          exec {
-            command = ["ls", "-al"]
+      volume_mount {
+        mount_path = "/scratch"
+        name       = "scratch"
+      }
+    }
+    volume {
+      name = "scratch"
+      ephemeral {
+        volume_claim_template {
+          metadata {
+            labels {
+              app = "%s"
+            }
           }
-        }
-        pre_stop {
-          exec {
-            command = ["date"]
+          spec {
+            access_modes = ["ReadWriteOnce"]
+            resources {
+              requests {
+                storage = "1Gi"
+              }
+            }
           }
         }
       }
     }
   }
 }
-
-	`, podName, imageName)
+`, podName, imageName, podName)
 }
 
-func testAccKubernetesPodConfigWithContainerSecurityContext(podName, imageName string) string {
+func testAccKubernetesPodConfigSeccompProfile(podName, imageName string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
   metadata {
-    labels {
-      app = "pod_label"
-    }
-
     name = "%s"
   }
   spec {
+    security_context {
+      seccomp_profile {
+        type = "RuntimeDefault"
+      }
+    }
     container {
       image = "%s"
       name  = "containername"
-
       security_context {
-        privileged  = true
-        run_as_user = 1
-        se_linux_options {
-          level = "s0:c123,c456"
-        }
-        capabilities {
-          add = ["NET_ADMIN", "SYS_TIME"]
+        seccomp_profile {
+          type = "Unconfined"
         }
       }
     }
@@ -772,51 +1748,64 @@ resource "kubernetes_pod" "test" {
 `, podName, imageName)
 }
 
-func testAccKubernetesPodConfigWithVolumeMounts(secretName, podName, imageName string) string {
+func testAccKubernetesPodConfigTopologySpreadConstraint(podName, imageName string) string {
 	return fmt.Sprintf(`
-
-resource "kubernetes_secret" "test" {
+resource "kubernetes_pod" "test" {
   metadata {
     name = "%s"
+    labels {
+      app = "%s"
+    }
   }
-
-  data {
-    one = "first"
+  spec {
+    topology_spread_constraint {
+      topology_key       = "kubernetes.io/hostname"
+      when_unsatisfiable = "ScheduleAnyway"
+      label_selector {
+        match_labels {
+          app = "%s"
+        }
+      }
+    }
+    container {
+      image = "%s"
+      name  = "containername"
+    }
   }
 }
+`, podName, podName, podName, imageName)
+}
 
+func testAccKubernetesPodConfigTolerations(podName, imageName string) string {
+	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
   metadata {
-    labels {
-      app = "pod_label"
-    }
-
     name = "%s"
   }
-
   spec {
     container {
       image = "%s"
       name  = "containername"
-      volume_mount {
-        mount_path = "/tmp/my_path"
-        name       = "db"
-      }
     }
-    volume {
-      name = "db"
-      secret {
-        secret_name = "${kubernetes_secret.test.metadata.0.name}"
-      }
+    toleration {
+      key      = "dedicated"
+      operator = "Equal"
+      value    = "tf-acc-test"
+      effect   = "NoSchedule"
+    }
+    toleration {
+      key                = "node.kubernetes.io/unreachable"
+      operator           = "Exists"
+      effect             = "NoExecute"
+      toleration_seconds = 60
     }
   }
 }
-	`, secretName, podName, imageName)
+`, podName, imageName)
 }
 
-func testAccKubernetesPodConfigWithSecretItemsVolume(secretName, podName, imageName string) string {
+func testAccKubernetesPodConfigEnvFrom(secretName, configMapName, podName, imageName string) string {
 	return fmt.Sprintf(`
-
 resource "kubernetes_secret" "test" {
   metadata {
     name = "%s"
@@ -827,12 +1816,18 @@ resource "kubernetes_secret" "test" {
   }
 }
 
-resource "kubernetes_pod" "test" {
+resource "kubernetes_config_map" "test" {
   metadata {
-    labels {
-      app = "pod_label"
-    }
+    name = "%s"
+  }
 
+  data {
+    one = "ONE"
+  }
+}
+
+resource "kubernetes_pod" "test" {
+  metadata {
     name = "%s"
   }
 
@@ -840,29 +1835,28 @@ resource "kubernetes_pod" "test" {
     container {
       image = "%s"
       name  = "containername"
-      volume_mount {
-        mount_path = "/tmp/my_path"
-        name       = "db"
+
+      env_from {
+        config_map_ref {
+          name = "${kubernetes_config_map.test.metadata.0.name}"
+        }
+        prefix = "CONFIG_"
       }
-    }
-    volume {
-      name = "db"
-      secret {
-        secret_name = "${kubernetes_secret.test.metadata.0.name}"
-        items {
-          key = "one"
-          path = "path/to/one"
+      env_from {
+        secret_ref {
+          name     = "${kubernetes_secret.test.metadata.0.name}"
+          optional = true
         }
       }
     }
   }
 }
-	`, secretName, podName, imageName)
+`, secretName, configMapName, podName, imageName)
 }
 
-func testAccKubernetesPodConfigWithConfigMapVolume(secretName, podName, imageName string) string {
+func testAccKubernetesPodConfigProjectedVolume(secretName, configMapName, podName, imageName string) string {
 	return fmt.Sprintf(`
-resource "kubernetes_config_map" "test" {
+resource "kubernetes_secret" "test" {
   metadata {
     name = "%s"
   }
@@ -872,94 +1866,137 @@ resource "kubernetes_config_map" "test" {
   }
 }
 
+resource "kubernetes_config_map" "test" {
+  metadata {
+    name = "%s"
+  }
+
+  data {
+    one = "ONE"
+  }
+}
+
 resource "kubernetes_pod" "test" {
   metadata {
+    name = "%s"
     labels {
-      app = "pod_label"
+      app = "projected"
     }
-
-    name = "%s"
   }
 
   spec {
     container {
       image = "%s"
       name  = "containername"
+
       volume_mount {
-        mount_path = "/tmp/my_path"
-        name       = "cfg"
+        name       = "config"
+        mount_path = "/etc/config"
       }
     }
     volume {
-      name = "cfg"
-      config_map {
-        name = "${kubernetes_config_map.test.metadata.0.name}"
-        default_mode = 0777
+      name = "config"
+      projected {
+        sources {
+          secret {
+            name = "${kubernetes_secret.test.metadata.0.name}"
+            items {
+              key  = "one"
+              path = "secret/one"
+            }
+          }
+        }
+        sources {
+          config_map {
+            name = "${kubernetes_config_map.test.metadata.0.name}"
+          }
+        }
+        sources {
+          downward_api {
+            items {
+              path = "labels"
+              field_ref {
+                field_path = "metadata.labels"
+              }
+            }
+          }
+        }
+        sources {
+          service_account_token {
+            audience           = "vault"
+            expiration_seconds = 7200
+            path               = "token"
+          }
+        }
       }
     }
   }
 }
-	`, secretName, podName, imageName)
+`, secretName, configMapName, podName, imageName)
 }
 
-func testAccKubernetesPodConfigWithResourceRequirements(podName, imageName string) string {
+func testAccKubernetesPodConfigDownwardAPI(podName, imageName string) string {
 	return fmt.Sprintf(`
-
 resource "kubernetes_pod" "test" {
   metadata {
+    name = "%s"
     labels {
-      app = "pod_label"
+      app = "downward-api"
     }
-
-    name = "%s"
   }
 
   spec {
     container {
       image = "%s"
       name  = "containername"
-		
-			resources{
-				limits{
-					cpu = "0.5"
-					memory = "512Mi"
-				}
-				requests{
-					cpu = "250m"
-				  memory = "50Mi"
-				}
-			}
-			
-    }
-  }
-}
-	`, podName, imageName)
-}
 
-func testAccKubernetesPodConfigWithEmptyDirVolumes(podName, imageName string) string {
-	return fmt.Sprintf(`
-resource "kubernetes_pod" "test" {
-  metadata {
-    labels {
-      app = "pod_label"
-    }
+      env {
+        name = "POD_NAMESPACE"
+        value_from {
+          field_ref {
+            field_path = "metadata.namespace"
+          }
+        }
+      }
+      env {
+        name = "MEMORY_LIMIT_MI"
+        value_from {
+          resource_field_ref {
+            resource = "limits.memory"
+            divisor  = "1Mi"
+          }
+        }
+      }
 
-    name = "%s"
-  }
+      resources {
+        limits {
+          cpu    = "250m"
+          memory = "64Mi"
+        }
+      }
 
-  spec {
-    container {
-      image = "%s"
-      name  = "containername"
       volume_mount {
-        mount_path =  "/cache"
-        name =  "cache-volume"
+        name       = "podinfo"
+        mount_path = "/etc/podinfo"
       }
     }
     volume {
-      name = "cache-volume"
-      empty_dir = {
-        medium = "Memory"
+      name = "podinfo"
+      downward_api {
+        items {
+          path = "labels"
+          field_ref {
+            field_path = "metadata.labels"
+          }
+        }
+        items {
+          path = "cpu_limit"
+          resource_field_ref {
+            container_name = "containername"
+            resource       = "limits.cpu"
+            divisor        = "1m"
+          }
+        }
       }
     }
   }
@@ -967,7 +2004,7 @@ resource "kubernetes_pod" "test" {
 `, podName, imageName)
 }
 
-func testAccKubernetesPodConfigNodeSelector(podName, imageName, region string) string {
+func testAccKubernetesPodConfigArgsUpdate(podName, imageName, args string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
   metadata {
@@ -977,30 +2014,32 @@ resource "kubernetes_pod" "test" {
   spec {
     container {
       image = "%s"
+      args = %s
       name  = "containername"
     }
-    node_selector {
-      "failure-domain.beta.kubernetes.io/region" = "%s"
-    }
   }
 }
-`, podName, imageName, region)
+`, podName, imageName, args)
 }
 
-func testAccKubernetesPodConfigArgsUpdate(podName, imageName, args string) string {
+func testAccKubernetesPodConfigMeshInjection(podName, imageName, provider string, enabled bool) string {
 	return fmt.Sprintf(`
 resource "kubernetes_pod" "test" {
   metadata {
     name = "%s"
   }
 
+  mesh_injection {
+    provider = "%s"
+    enabled  = %t
+  }
+
   spec {
     container {
       image = "%s"
-      args = %s
       name  = "containername"
     }
   }
 }
-`, podName, imageName, args)
+`, podName, provider, enabled, imageName)
 }
@@ -0,0 +1,342 @@
+package kubernetes
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func resourceKubernetesReplicaSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesReplicaSetCreate,
+		Read:   resourceKubernetesReplicaSetRead,
+		Exists: resourceKubernetesReplicaSetExists,
+		Update: resourceKubernetesReplicaSetUpdate,
+		Delete: resourceKubernetesReplicaSetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"metadata":       namespacedMetadataSchema("replica set", true),
+			"mesh_injection": meshInjectionSchema(true),
+			"spec": {
+				Type:        schema.TypeList,
+				Description: "Spec defines the specification of the desired behavior of the replica set. More info: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#spec-and-status",
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"min_ready_seconds": {
+							Type:        schema.TypeInt,
+							Description: "Minimum number of seconds for which a newly created pod should be ready without any of its container crashing, for it to be considered available. Defaults to 0 (pod will be considered available as soon as it is ready)",
+							Optional:    true,
+							Default:     0,
+						},
+						"replicas": {
+							Type:        schema.TypeInt,
+							Description: "The number of desired replicas. Defaults to 1. More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicaset/",
+							Optional:    true,
+							Default:     1,
+						},
+						"selector": {
+							Type:        schema.TypeList,
+							Description: "A label query over pods that should match the replicas count. `match_labels` are also applied to the pod template. More info: http://kubernetes.io/docs/user-guide/labels#label-selectors",
+							Required:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"match_expressions": {
+										Type:        schema.TypeList,
+										Description: "A list of label selector requirements. The requirements are ANDed.",
+										Optional:    true,
+										ForceNew:    true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"key": {
+													Type:        schema.TypeString,
+													Description: "The label key that the selector applies to.",
+													Optional:    true,
+													ForceNew:    true,
+												},
+												"operator": {
+													Type:        schema.TypeString,
+													Description: "A key's relationship to a set of values. Valid operators ard `In`, `NotIn`, `Exists` and `DoesNotExist`.",
+													Optional:    true,
+													ForceNew:    true,
+												},
+												"values": {
+													Type:        schema.TypeSet,
+													Description: "An array of string values. If the operator is `In` or `NotIn`, the values array must be non-empty. If the operator is `Exists` or `DoesNotExist`, the values array must be empty. This array is replaced during a strategic merge patch.",
+													Optional:    true,
+													ForceNew:    true,
+													Elem:        &schema.Schema{Type: schema.TypeString},
+													Set:         schema.HashString,
+												},
+											},
+										},
+									},
+									"match_labels": {
+										Type:        schema.TypeMap,
+										Description: "A map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of `match_expressions`, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+										Required:    true,
+										ForceNew:    true,
+									},
+								},
+							},
+						},
+						"template": {
+							Type:        schema.TypeList,
+							Description: "Describes the pod that will be created if insufficient replicas are detected. More info: https://kubernetes.io/docs/concepts/workloads/controllers/replicaset/#pod-template",
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: podSpecFields(true),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesReplicaSetCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	metadata := expandMetadata(d.Get("metadata").([]interface{}))
+	spec, err := expandReplicaSetSpec(d.Get("spec").([]interface{}))
+	if err != nil {
+		return err
+	}
+	expandExplicitZeroValues(d, "spec.0.template.0.", &spec.Template.Spec)
+
+	spec.Template.Spec.AutomountServiceAccountToken = ptrToBool(false)
+	spec.Template.Annotations = expandMeshInjection(d.Get("mesh_injection").([]interface{}), spec.Template.Annotations)
+
+	rs := replicaSetWithNewerSpec{
+		ObjectMeta: metadata,
+		Spec:       spec,
+	}
+
+	log.Printf("[INFO] Creating new replica set: %#v", rs)
+	var out replicaSetWithNewerSpec
+	err = objectKinds["ReplicaSet"].create(conn, metadata.Namespace, &rs, &out)
+	if err != nil {
+		return wrapError(err, "Failed to create replica set")
+	}
+
+	d.SetId(buildId(out.ObjectMeta))
+
+	log.Printf("[DEBUG] Waiting for replica set %s to schedule %d replicas",
+		d.Id(), *out.Spec.Replicas)
+	err = waitWithEvents(conn, out.ObjectMeta, "ReplicaSet", func() error {
+		return resource.Retry(d.Timeout(schema.TimeoutCreate),
+			waitForDesiredReplicaSetReplicasFunc(conn, out.GetNamespace(), out.GetName()))
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Submitted new replica set: %#v", out)
+
+	return resourceKubernetesReplicaSetRead(d, meta)
+}
+
+func resourceKubernetesReplicaSetRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading replica set %s", name)
+	var rs replicaSetWithNewerSpec
+	err = objectKinds["ReplicaSet"].get(conn, namespace, name, &rs)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received replica set: %#v", rs)
+
+	err = d.Set("metadata", flattenMetadata(rs.ObjectMeta))
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("mesh_injection", flattenMeshInjection(rs.Spec.Template.Annotations))
+	if err != nil {
+		return err
+	}
+
+	spec, err := flattenReplicaSetSpec(rs.Spec)
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("spec", spec)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func resourceKubernetesReplicaSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	ops := patchMetadata("metadata.0.", "/metadata/", d)
+
+	if d.HasChange("spec") || d.HasChange("mesh_injection") {
+		spec, err := expandReplicaSetSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			return err
+		}
+		expandExplicitZeroValues(d, "spec.0.template.0.", &spec.Template.Spec)
+		spec.Template.Spec.AutomountServiceAccountToken = ptrToBool(false)
+		spec.Template.Annotations = expandMeshInjection(d.Get("mesh_injection").([]interface{}), spec.Template.Annotations)
+
+		ops = append(ops, &ReplaceOperation{
+			Path:  "/spec",
+			Value: spec,
+		})
+	}
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+	log.Printf("[INFO] Updating replica set %q: %v", name, string(data))
+	var out replicaSetWithNewerSpec
+	err = objectKinds["ReplicaSet"].patch(conn, namespace, name, pkgApi.JSONPatchType, data, &out)
+	if err != nil {
+		return wrapError(err, "Failed to update replica set")
+	}
+	log.Printf("[INFO] Submitted updated replica set: %#v", out)
+
+	err = waitWithEvents(conn, out.ObjectMeta, "ReplicaSet", func() error {
+		return resource.Retry(d.Timeout(schema.TimeoutUpdate),
+			waitForDesiredReplicaSetReplicasFunc(conn, namespace, name))
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceKubernetesReplicaSetRead(d, meta)
+}
+
+func resourceKubernetesReplicaSetDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Deleting replica set: %#v", name)
+
+	// Drain all replicas before deleting
+	var ops PatchOperations
+	ops = append(ops, &ReplaceOperation{
+		Path:  "/spec/replicas",
+		Value: 0,
+	})
+	data, err := ops.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	var out replicaSetWithNewerSpec
+	err = objectKinds["ReplicaSet"].patch(conn, namespace, name, pkgApi.JSONPatchType, data, &out)
+	if err != nil {
+		return err
+	}
+
+	// Wait until all replicas are gone
+	err = waitWithEvents(conn, out.ObjectMeta, "ReplicaSet", func() error {
+		return resource.Retry(d.Timeout(schema.TimeoutDelete),
+			waitForDesiredReplicaSetReplicasFunc(conn, namespace, name))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = objectKinds["ReplicaSet"].delete(conn, namespace, name)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Replica set %s deleted", name)
+
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesReplicaSetExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking replica set %s", name)
+	var rs replicaSetWithNewerSpec
+	err = objectKinds["ReplicaSet"].get(conn, namespace, name, &rs)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func waitForDesiredReplicaSetReplicasFunc(conn *kubernetes.Clientset, ns, name string) resource.RetryFunc {
+	return func() *resource.RetryError {
+		var rs replicaSetWithNewerSpec
+		err := objectKinds["ReplicaSet"].get(conn, ns, name, &rs)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		desiredReplicas := *rs.Spec.Replicas
+		log.Printf("[DEBUG] Current number of labelled replicas of %q: %d (of %d)\n",
+			rs.GetName(), rs.Status.FullyLabeledReplicas, desiredReplicas)
+
+		if rs.Status.FullyLabeledReplicas == desiredReplicas {
+			return nil
+		}
+
+		return resource.RetryableError(fmt.Errorf("Waiting for %d replicas of %q to be scheduled (%d)",
+			desiredReplicas, rs.GetName(), rs.Status.FullyLabeledReplicas))
+	}
+}
+
+// replicaSetWithNewerSpec is a replica set including pod spec fields
+// which the vendored API types predate
+type replicaSetWithNewerSpec struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              replicaSetSpec           `json:"spec,omitempty"`
+	Status            v1beta1.ReplicaSetStatus `json:"status,omitempty"`
+}
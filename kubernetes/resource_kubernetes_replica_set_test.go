@@ -0,0 +1,290 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesReplicaSet_basic(t *testing.T) {
+	var conf v1beta1.ReplicaSet
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_replica_set.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesReplicaSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesReplicaSetConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesReplicaSetExists("kubernetes_replica_set.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "metadata.0.annotations.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "metadata.0.annotations.TestAnnotationOne", "one"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{"TestAnnotationOne": "one"}),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "metadata.0.labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "metadata.0.labels.TestLabelOne", "one"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.generation"),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.resource_version"),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.self_link"),
+					resource.TestCheckResourceAttrSet("kubernetes_replica_set.test", "metadata.0.uid"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.replicas", "2"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.selector.0.match_labels.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.selector.0.match_labels.TestLabelOne", "one"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.template.0.container.0.image", "nginx:1.7.8"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.template.0.container.0.name", "tf-acc-test"),
+				),
+			},
+			{
+				Config: testAccKubernetesReplicaSetConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesReplicaSetExists("kubernetes_replica_set.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "metadata.0.name", name),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.replicas", "3"),
+					resource.TestCheckResourceAttr("kubernetes_replica_set.test", "spec.0.template.0.container.0.image", "nginx:1.7.9"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesReplicaSet_importBasic(t *testing.T) {
+	resourceName := "kubernetes_replica_set.test"
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesReplicaSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesReplicaSetConfig_basic(name),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesReplicaSetDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubernetes_replica_set" {
+			continue
+		}
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		var resp v1beta1.ReplicaSet
+		err = objectKinds["ReplicaSet"].get(conn, namespace, name, &resp)
+		if err == nil {
+			if resp.Namespace == namespace && resp.Name == name {
+				return fmt.Errorf("Replica set still exists: %s", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckKubernetesReplicaSetExists(n string, obj *v1beta1.ReplicaSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+
+		namespace, name, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		return objectKinds["ReplicaSet"].get(conn, namespace, name, obj)
+	}
+}
+
+func TestReplicaSet_appsV1Only(t *testing.T) {
+	// Servers since Kubernetes 1.16 only serve replica sets from apps/v1
+	const path = "/apis/apps/v1/namespaces/default/replicasets/web"
+	requests := make([]string, 0)
+	var patch []map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.URL.Path != path {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == "PATCH" {
+			raw, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(raw, &patch)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"metadata": {"name": "web", "namespace": "default"},
+			"spec": {"replicas": 2, "selector": {"matchLabels": {"app": "web"}},
+				"template": {"metadata": {"labels": {"app": "web"}}, "spec": {
+					"containers": [{"name": "web", "image": "nginx:1.7.9", "terminationMessagePath": "/dev/termination-log"}],
+					"dnsPolicy": "ClusterFirst", "restartPolicy": "Always", "terminationGracePeriodSeconds": 30}}},
+			"status": {"replicas": 2, "fullyLabeledReplicas": 2}}`))
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Refresh, Apply and destroy go through Exists and Read, then Update
+	// and Delete, like Terraform does
+	r := resourceKubernetesReplicaSet()
+	state, err := r.Refresh(&terraform.InstanceState{ID: "default/web"}, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state == nil || state.Attributes["spec.0.replicas"] != "2" {
+		t.Fatalf("Expected the replica set to be read, got %#v", state)
+	}
+
+	raw, err := config.NewRawConfig(map[string]interface{}{
+		"metadata": []interface{}{
+			map[string]interface{}{"name": "web", "namespace": "default"},
+		},
+		"spec": []interface{}{
+			map[string]interface{}{
+				"replicas": 3,
+				"selector": []interface{}{
+					map[string]interface{}{
+						"match_labels": map[string]interface{}{"app": "web"},
+					},
+				},
+				"template": []interface{}{
+					map[string]interface{}{
+						"container": []interface{}{
+							map[string]interface{}{"name": "web", "image": "nginx:1.7.9"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := r.Diff(state, terraform.NewResourceConfig(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff == nil || diff.RequiresNew() {
+		t.Fatalf("Expected an update, got %#v", diff)
+	}
+	state, err = r.Apply(state, diff, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patch) == 0 || patch[len(patch)-1]["path"] != "/spec" {
+		t.Fatalf("Expected the spec to be patched, got %#v", patch)
+	}
+
+	_, err = r.Apply(state, &terraform.InstanceDiff{Destroy: true}, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Exists, Read, Update, the waiter and Delete must all use apps/v1
+	for _, req := range requests {
+		if !strings.HasSuffix(req, " "+path) {
+			t.Fatalf("Expected only apps/v1 requests, got %q", requests)
+		}
+	}
+	for _, method := range []string{"PATCH", "GET", "DELETE"} {
+		found := false
+		for _, req := range requests {
+			found = found || strings.HasPrefix(req, method+" ")
+		}
+		if !found {
+			t.Fatalf("Expected a %s request, got %q", method, requests)
+		}
+	}
+}
+
+func testAccKubernetesReplicaSetConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_replica_set" "test" {
+  metadata {
+    annotations {
+      TestAnnotationOne = "one"
+    }
+    labels {
+      TestLabelOne = "one"
+    }
+    name = "%s"
+  }
+  spec {
+    replicas = 2
+    selector {
+      match_labels {
+        TestLabelOne = "one"
+      }
+    }
+    template {
+      container {
+        image = "nginx:1.7.8"
+        name  = "tf-acc-test"
+      }
+    }
+  }
+}
+`, name)
+}
+
+func testAccKubernetesReplicaSetConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_replica_set" "test" {
+  metadata {
+    annotations {
+      TestAnnotationOne = "one"
+    }
+    labels {
+      TestLabelOne = "one"
+    }
+    name = "%s"
+  }
+  spec {
+    replicas = 3
+    selector {
+      match_labels {
+        TestLabelOne = "one"
+      }
+    }
+    template {
+      container {
+        image = "nginx:1.7.9"
+        name  = "tf-acc-test"
+      }
+    }
+  }
+}
+`, name)
+}
@@ -32,7 +32,8 @@ func resourceKubernetesReplicationController() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
-			"metadata": namespacedMetadataSchema("replication controller", true),
+			"metadata":       namespacedMetadataSchema("replication controller", true),
+			"mesh_injection": meshInjectionSchema(true),
 			"spec": {
 				Type:        schema.TypeList,
 				Description: "Spec defines the specification of the desired behavior of the replication controller. More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#spec-and-status",
@@ -81,18 +82,21 @@ func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta
 	if err != nil {
 		return err
 	}
+	expandExplicitZeroValues(d, "spec.0.template.0.", &spec.Template.Spec)
 
 	spec.Template.Spec.AutomountServiceAccountToken = ptrToBool(false)
+	spec.Template.Annotations = expandMeshInjection(d.Get("mesh_injection").([]interface{}), spec.Template.Annotations)
 
-	rc := api.ReplicationController{
+	rc := replicationControllerWithNewerSpec{
 		ObjectMeta: metadata,
 		Spec:       spec,
 	}
 
 	log.Printf("[INFO] Creating new replication controller: %#v", rc)
-	out, err := conn.CoreV1().ReplicationControllers(metadata.Namespace).Create(&rc)
+	var out replicationControllerWithNewerSpec
+	err = objectKinds["ReplicationController"].create(conn, metadata.Namespace, &rc, &out)
 	if err != nil {
-		return fmt.Errorf("Failed to create replication controller: %s", err)
+		return wrapError(err, "Failed to create replication controller")
 	}
 
 	d.SetId(buildId(out.ObjectMeta))
@@ -100,8 +104,10 @@ func resourceKubernetesReplicationControllerCreate(d *schema.ResourceData, meta
 	log.Printf("[DEBUG] Waiting for replication controller %s to schedule %d replicas",
 		d.Id(), *out.Spec.Replicas)
 	// 10 mins should be sufficient for scheduling ~10k replicas
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate),
-		waitForDesiredReplicasFunc(conn, out.GetNamespace(), out.GetName()))
+	err = waitWithEvents(conn, out.ObjectMeta, "ReplicationController", func() error {
+		return resource.Retry(d.Timeout(schema.TimeoutCreate),
+			waitForDesiredReplicasFunc(conn, out.GetNamespace(), out.GetName()))
+	})
 	if err != nil {
 		return err
 	}
@@ -123,7 +129,8 @@ func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta in
 	}
 
 	log.Printf("[INFO] Reading replication controller %s", name)
-	rc, err := conn.CoreV1().ReplicationControllers(namespace).Get(name, metav1.GetOptions{})
+	var rc replicationControllerWithNewerSpec
+	err = objectKinds["ReplicationController"].get(conn, namespace, name, &rc)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
@@ -135,6 +142,13 @@ func resourceKubernetesReplicationControllerRead(d *schema.ResourceData, meta in
 		return err
 	}
 
+	if rc.Spec.Template != nil {
+		err = d.Set("mesh_injection", flattenMeshInjection(rc.Spec.Template.Annotations))
+		if err != nil {
+			return err
+		}
+	}
+
 	spec, err := flattenReplicationControllerSpec(rc.Spec)
 	if err != nil {
 		return err
@@ -158,11 +172,13 @@ func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 
-	if d.HasChange("spec") {
+	if d.HasChange("spec") || d.HasChange("mesh_injection") {
 		spec, err := expandReplicationControllerSpec(d.Get("spec").([]interface{}))
 		if err != nil {
 			return err
 		}
+		expandExplicitZeroValues(d, "spec.0.template.0.", &spec.Template.Spec)
+		spec.Template.Annotations = expandMeshInjection(d.Get("mesh_injection").([]interface{}), spec.Template.Annotations)
 
 		ops = append(ops, &ReplaceOperation{
 			Path:  "/spec",
@@ -176,12 +192,14 @@ func resourceKubernetesReplicationControllerUpdate(d *schema.ResourceData, meta
 	log.Printf("[INFO] Updating replication controller %q: %v", name, string(data))
 	out, err := conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update replication controller: %s", err)
+		return wrapError(err, "Failed to update replication controller")
 	}
 	log.Printf("[INFO] Submitted updated replication controller: %#v", out)
 
-	err = resource.Retry(d.Timeout(schema.TimeoutUpdate),
-		waitForDesiredReplicasFunc(conn, namespace, name))
+	err = waitWithEvents(conn, out.ObjectMeta, "ReplicationController", func() error {
+		return resource.Retry(d.Timeout(schema.TimeoutUpdate),
+			waitForDesiredReplicasFunc(conn, namespace, name))
+	})
 	if err != nil {
 		return err
 	}
@@ -209,14 +227,16 @@ func resourceKubernetesReplicationControllerDelete(d *schema.ResourceData, meta
 	if err != nil {
 		return err
 	}
-	_, err = conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
+	out, err := conn.CoreV1().ReplicationControllers(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
 		return err
 	}
 
 	// Wait until all replicas are gone
-	err = resource.Retry(d.Timeout(schema.TimeoutDelete),
-		waitForDesiredReplicasFunc(conn, namespace, name))
+	err = waitWithEvents(conn, out.ObjectMeta, "ReplicationController", func() error {
+		return resource.Retry(d.Timeout(schema.TimeoutDelete),
+			waitForDesiredReplicasFunc(conn, namespace, name))
+	})
 	if err != nil {
 		return err
 	}
@@ -270,3 +290,12 @@ func waitForDesiredReplicasFunc(conn *kubernetes.Clientset, ns, name string) res
 			desiredReplicas, rc.GetName(), rc.Status.FullyLabeledReplicas))
 	}
 }
+
+// replicationControllerWithNewerSpec is a replication controller including
+// pod spec fields which the vendored API types predate
+type replicationControllerWithNewerSpec struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              replicationControllerSpec       `json:"spec,omitempty"`
+	Status            api.ReplicationControllerStatus `json:"status,omitempty"`
+}
@@ -71,7 +71,7 @@ func resourceKubernetesResourceQuotaCreate(d *schema.ResourceData, meta interfac
 	log.Printf("[INFO] Creating new resource quota: %#v", resQuota)
 	out, err := conn.CoreV1().ResourceQuotas(metadata.Namespace).Create(&resQuota)
 	if err != nil {
-		return fmt.Errorf("Failed to create resource quota: %s", err)
+		return wrapError(err, "Failed to create resource quota")
 	}
 	log.Printf("[INFO] Submitted new resource quota: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -161,7 +161,7 @@ func resourceKubernetesResourceQuotaUpdate(d *schema.ResourceData, meta interfac
 	log.Printf("[INFO] Updating resource quota %q: %v", name, string(data))
 	out, err := conn.CoreV1().ResourceQuotas(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update resource quota: %s", err)
+		return wrapError(err, "Failed to update resource quota")
 	}
 	log.Printf("[INFO] Submitted updated resource quota: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
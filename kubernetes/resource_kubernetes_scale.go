@@ -0,0 +1,167 @@
+package kubernetes
+
+import (
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func resourceKubernetesScale() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesScaleCreate,
+		Read:   resourceKubernetesScaleRead,
+		Exists: resourceKubernetesScaleExists,
+		Update: resourceKubernetesScaleUpdate,
+		Delete: resourceKubernetesScaleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"kind": {
+				Type:         schema.TypeString,
+				Description:  "Kind of the scaled object. One of `Deployment`, `ReplicaSet`, `ReplicationController` or `StatefulSet`.",
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAttributeValueIsIn(scalableObjectKinds),
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the scaled object.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the scaled object.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"replicas": {
+				Type:         schema.TypeInt,
+				Description:  "The desired number of replicas of the object.",
+				Required:     true,
+				ValidateFunc: validateNonNegativeInteger,
+			},
+			"current_replicas": {
+				Type:        schema.TypeInt,
+				Description: "The number of replicas currently observed by the controller of the object.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesScaleCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind := d.Get("kind").(string)
+	namespace := d.Get("namespace").(string)
+	name := d.Get("name").(string)
+
+	err := setObjectReplicas(conn, kind, namespace, name, int32(d.Get("replicas").(int)))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(buildObjectId(kind, namespace, name))
+
+	return resourceKubernetesScaleRead(d, meta)
+}
+
+func resourceKubernetesScaleRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind, namespace, name, err := objectIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reading scale of %s %s", kind, name)
+	scale, err := getObjectScale(conn, kind, namespace, name)
+	if err != nil {
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+	log.Printf("[INFO] Received scale: %#v", scale)
+
+	d.Set("kind", kind)
+	d.Set("namespace", namespace)
+	d.Set("name", name)
+	d.Set("replicas", scale.Replicas)
+	d.Set("current_replicas", scale.CurrentReplicas)
+
+	return nil
+}
+
+func resourceKubernetesScaleUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind, namespace, name, err := objectIdParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("replicas") {
+		err = setObjectReplicas(conn, kind, namespace, name, int32(d.Get("replicas").(int)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceKubernetesScaleRead(d, meta)
+}
+
+func resourceKubernetesScaleDelete(d *schema.ResourceData, meta interface{}) error {
+	// The object is owned by someone else, leave it at its current size
+	log.Printf("[INFO] Releasing scale of %s", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceKubernetesScaleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	conn := meta.(*kubernetes.Clientset)
+
+	kind, namespace, name, err := objectIdParts(d.Id())
+	if err != nil {
+		return false, err
+	}
+
+	log.Printf("[INFO] Checking scale of %s %s", kind, name)
+	_, err = getObjectScale(conn, kind, namespace, name)
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return false, nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+	}
+	return true, err
+}
+
+func setObjectReplicas(conn *kubernetes.Clientset, kind, namespace, name string, replicas int32) error {
+	log.Printf("[INFO] Scaling %s %s/%s to %d replicas", kind, namespace, name, replicas)
+	metadata := metav1.ObjectMeta{Namespace: namespace, Name: name}
+	return waitWithEvents(conn, metadata, kind, func() error {
+		return resource.Retry(1*time.Minute, func() *resource.RetryError {
+			scale, err := getObjectScale(conn, kind, namespace, name)
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			scale.Replicas = replicas
+			err = updateObjectScale(conn, kind, namespace, name, scale)
+			if err != nil {
+				if errors.IsConflict(err) {
+					return resource.RetryableError(err)
+				}
+				return resource.NonRetryableError(err)
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestAccKubernetesScale_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_scale.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesReplicationControllerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesScaleConfig_basic(name, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_scale.test", "kind", "ReplicationController"),
+					resource.TestCheckResourceAttr("kubernetes_scale.test", "namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_scale.test", "name", name),
+					resource.TestCheckResourceAttr("kubernetes_scale.test", "replicas", "2"),
+					testAccCheckKubernetesScale("kubernetes_scale.test", 2),
+				),
+			},
+			{
+				Config: testAccKubernetesScaleConfig_basic(name, 0),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_scale.test", "replicas", "0"),
+					testAccCheckKubernetesScale("kubernetes_scale.test", 0),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKubernetesScale(n string, expected int32) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		conn := testAccProvider.Meta().(*kubernetes.Clientset)
+		kind, namespace, name, err := objectIdParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		scale, err := getObjectScale(conn, kind, namespace, name)
+		if err != nil {
+			return err
+		}
+		if scale.Replicas != expected {
+			return fmt.Errorf("Expected %d replicas of %s, got %d", expected, name, scale.Replicas)
+		}
+		return nil
+	}
+}
+
+func testAccKubernetesScaleConfig_basic(name string, replicas int) string {
+	return fmt.Sprintf(`
+resource "kubernetes_replication_controller" "test" {
+  metadata {
+    name = "%s"
+  }
+  spec {
+    replicas = 1
+    selector {
+      app = "%s"
+    }
+    template {
+      container {
+        image = "nginx:1.7.8"
+        name  = "tf-acc-test"
+      }
+    }
+  }
+
+  lifecycle {
+    ignore_changes = ["spec.0.replicas"]
+  }
+}
+
+resource "kubernetes_scale" "test" {
+  kind     = "ReplicationController"
+  name     = "${kubernetes_replication_controller.test.metadata.0.name}"
+  replicas = %d
+}
+`, name, name, replicas)
+}
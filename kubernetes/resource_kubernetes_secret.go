@@ -1,9 +1,10 @@
 package kubernetes
 
 import (
+	"encoding/base64"
+	"fmt"
 	"log"
 
-	"fmt"
 	"github.com/hashicorp/terraform/helper/schema"
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -26,16 +27,79 @@ func resourceKubernetesSecret() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("secret", true),
 			"data": {
-				Type:        schema.TypeMap,
-				Description: "A map of the secret data.",
+				Type:          schema.TypeMap,
+				Description:   "A map of the secret data.",
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"docker_registry", "tls_crt", "tls_key"},
+			},
+			"binary_data": {
+				Type:          schema.TypeMap,
+				Description:   "A map of base64 encoded binary secret data, for values which aren't valid UTF-8 (e.g. keystores).",
+				Optional:      true,
+				Sensitive:     true,
+				ValidateFunc:  validateBase64EncodedMap,
+				ConflictsWith: []string{"docker_registry", "tls_crt", "tls_key"},
+			},
+			"docker_registry": {
+				Type:          schema.TypeList,
+				Description:   "Credentials of a Docker registry, rendered into the `.dockerconfigjson` key of a `kubernetes.io/dockerconfigjson` secret.",
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"data", "binary_data", "tls_crt", "tls_key"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server": {
+							Type:        schema.TypeString,
+							Description: "Address of the registry, e.g. `https://index.docker.io/v1/` or `registry.example.com`.",
+							Required:    true,
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Description: "Username to log in to the registry with.",
+							Optional:    true,
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Description: "Password to log in to the registry with.",
+							Optional:    true,
+							Sensitive:   true,
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Description: "Email address of the user.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"tls_crt": {
+				Type:          schema.TypeString,
+				Description:   "PEM-encoded certificate (chain) stored in the `tls.crt` key of a `kubernetes.io/tls` secret. Must be set together with `tls_key`.",
+				Optional:      true,
+				ConflictsWith: []string{"data", "binary_data", "docker_registry"},
+				ValidateFunc:  validatePEMCertificate,
+			},
+			"tls_key": {
+				Type:          schema.TypeString,
+				Description:   "PEM-encoded private key of the certificate stored in the `tls.key` key of a `kubernetes.io/tls` secret. Must be set together with `tls_crt`.",
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"data", "binary_data", "docker_registry"},
+				ValidateFunc:  validatePEMPrivateKey,
+			},
+			"ignore_keys": {
+				Type:        schema.TypeSet,
+				Description: "Keys of `data` and `binary_data` which are managed outside of Terraform, e.g. written by controllers. They're left untouched and not read into state.",
 				Optional:    true,
-				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
 			},
 			"type": {
 				Type:        schema.TypeString,
-				Description: "Type of secret",
-				Default:     "Opaque",
+				Description: "Type of secret. Defaults to `Opaque`, `kubernetes.io/dockerconfigjson` when `docker_registry` is set or `kubernetes.io/tls` when `tls_crt` and `tls_key` are set.",
 				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 			},
 		},
@@ -45,16 +109,55 @@ func resourceKubernetesSecret() *schema.Resource {
 func resourceKubernetesSecretCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*kubernetes.Clientset)
 
+	ignored := sliceOfString(d.Get("ignore_keys").(*schema.Set).List())
+	err := checkIgnoredKeys(ignored, d.Get("data").(map[string]interface{}), d.Get("binary_data").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
 	secret := api.Secret{
 		ObjectMeta: metadata,
 		StringData: expandStringMap(d.Get("data").(map[string]interface{})),
+		Data:       base64DecodeStringMap(d.Get("binary_data").(map[string]interface{})),
 	}
 
+	secret.Type = api.SecretTypeOpaque
 	if v, ok := d.GetOk("type"); ok {
 		secret.Type = api.SecretType(v.(string))
 	}
 
+	if v, ok := d.GetOk("docker_registry"); ok {
+		if t, ok := d.GetOk("type"); ok && t.(string) != string(api.SecretTypeDockerConfigJson) {
+			return fmt.Errorf("docker_registry can only be used with secrets of type %q, got %q", api.SecretTypeDockerConfigJson, t)
+		}
+		cfg, err := expandDockerRegistry(v.([]interface{}))
+		if err != nil {
+			return err
+		}
+		secret.Type = api.SecretTypeDockerConfigJson
+		secret.StringData = map[string]string{
+			api.DockerConfigJsonKey: cfg,
+		}
+	}
+
+	crt, crtOk := d.GetOk("tls_crt")
+	key, keyOk := d.GetOk("tls_key")
+	if crtOk || keyOk {
+		if t, ok := d.GetOk("type"); ok && t.(string) != string(api.SecretTypeTLS) {
+			return fmt.Errorf("tls_crt and tls_key can only be used with secrets of type %q, got %q", api.SecretTypeTLS, t)
+		}
+		err = validateTLSKeyPair(crt.(string), key.(string))
+		if err != nil {
+			return err
+		}
+		secret.Type = api.SecretTypeTLS
+		secret.StringData = map[string]string{
+			api.TLSCertKey:       crt.(string),
+			api.TLSPrivateKeyKey: key.(string),
+		}
+	}
+
 	log.Printf("[INFO] Creating new secret: %#v", secret)
 	out, err := conn.CoreV1().Secrets(metadata.Namespace).Create(&secret)
 	if err != nil {
@@ -87,7 +190,40 @@ func resourceKubernetesSecretRead(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	d.Set("data", byteMapToStringMap(secret.Data))
+	data := byteMapToStringMap(secret.Data)
+	if _, ok := d.GetOk("docker_registry"); ok {
+		registry, err := flattenDockerRegistry(data[api.DockerConfigJsonKey], d.Get("docker_registry.0.server").(string))
+		if err != nil {
+			return err
+		}
+		err = d.Set("docker_registry", registry)
+		if err != nil {
+			return err
+		}
+		delete(data, api.DockerConfigJsonKey)
+	}
+	if _, ok := d.GetOk("tls_crt"); ok {
+		d.Set("tls_crt", data[api.TLSCertKey])
+		d.Set("tls_key", data[api.TLSPrivateKeyKey])
+		delete(data, api.TLSCertKey)
+		delete(data, api.TLSPrivateKeyKey)
+	}
+
+	for _, k := range sliceOfString(d.Get("ignore_keys").(*schema.Set).List()) {
+		delete(data, k)
+	}
+
+	// Data doesn't tell binary values apart, use the keys we know are binary
+	binaryData := make(map[string][]byte)
+	for k := range d.Get("binary_data").(map[string]interface{}) {
+		if v, ok := secret.Data[k]; ok {
+			binaryData[k] = v
+			delete(data, k)
+		}
+	}
+	d.Set("binary_data", base64EncodeByteMap(binaryData))
+
+	d.Set("data", data)
 	d.Set("type", secret.Type)
 
 	return nil
@@ -101,17 +237,63 @@ func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) er
 		return err
 	}
 
+	// Keys ignored since the last refresh (e.g. right after import)
+	// are still in the old state, they mustn't be removed
+	ignored := sliceOfString(d.Get("ignore_keys").(*schema.Set).List())
+	err = checkIgnoredKeys(ignored, d.Get("data").(map[string]interface{}), d.Get("binary_data").(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 	if d.HasChange("data") {
 		oldV, newV := d.GetChange("data")
 
-		oldV = base64EncodeStringMap(oldV.(map[string]interface{}))
+		oldV = base64EncodeStringMap(withoutKeys(oldV.(map[string]interface{}), ignored))
 		newV = base64EncodeStringMap(newV.(map[string]interface{}))
 
 		diffOps := diffStringMap("/data/", oldV.(map[string]interface{}), newV.(map[string]interface{}))
 
 		ops = append(ops, diffOps...)
 	}
+	if d.HasChange("binary_data") {
+		oldV, newV := d.GetChange("binary_data")
+		diffOps := diffStringMap("/data/", withoutKeys(oldV.(map[string]interface{}), ignored), newV.(map[string]interface{}))
+		ops = append(ops, diffOps...)
+	}
+	if d.HasChange("docker_registry") {
+		cfg, err := expandDockerRegistry(d.Get("docker_registry").([]interface{}))
+		if err != nil {
+			return err
+		}
+		path := "/data/" + escapeJsonPointer(api.DockerConfigJsonKey)
+		if cfg == "" {
+			ops = append(ops, &RemoveOperation{Path: path})
+		} else {
+			ops = append(ops, &AddOperation{
+				Path:  path,
+				Value: base64.StdEncoding.EncodeToString([]byte(cfg)),
+			})
+		}
+	}
+	if d.HasChange("tls_crt") || d.HasChange("tls_key") {
+		crt := d.Get("tls_crt").(string)
+		key := d.Get("tls_key").(string)
+		err := validateTLSKeyPair(crt, key)
+		if err != nil {
+			return err
+		}
+		ops = append(ops,
+			&AddOperation{
+				Path:  "/data/" + escapeJsonPointer(api.TLSCertKey),
+				Value: base64.StdEncoding.EncodeToString([]byte(crt)),
+			},
+			&AddOperation{
+				Path:  "/data/" + escapeJsonPointer(api.TLSPrivateKeyKey),
+				Value: base64.StdEncoding.EncodeToString([]byte(key)),
+			},
+		)
+	}
 
 	data, err := ops.MarshalJSON()
 	if err != nil {
@@ -121,7 +303,7 @@ func resourceKubernetesSecretUpdate(d *schema.ResourceData, meta interface{}) er
 	log.Printf("[INFO] Updating secret %q: %v", name, data)
 	out, err := conn.CoreV1().Secrets(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update secret: %s", err)
+		return wrapError(err, "Failed to update secret")
 	}
 
 	log.Printf("[INFO] Submitting updated secret: %#v", out)
@@ -0,0 +1,9 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceKubernetesSecretData() *schema.Resource {
+	return objectDataResource("Secret", "secret")
+}
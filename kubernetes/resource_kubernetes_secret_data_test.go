@@ -0,0 +1,97 @@
+package kubernetes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccKubernetesSecretData_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_secret_data.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesSecretDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesSecretDataConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_secret_data.test", "namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_secret_data.test", "name", name),
+					resource.TestCheckResourceAttr("kubernetes_secret_data.test", "data.%", "2"),
+					resource.TestCheckResourceAttr("kubernetes_secret_data.test", "data.one", "first"),
+					resource.TestCheckResourceAttr("kubernetes_secret_data.test", "data.two", "second"),
+					testAccCheckKubernetesObjectData("Secret", "default", name, map[string]string{
+						"existing": "untouched",
+						"one":      "first",
+						"two":      "second",
+					}),
+				),
+			},
+			{
+				Config: testAccKubernetesSecretDataConfig_modified(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_secret_data.test", "data.%", "1"),
+					resource.TestCheckResourceAttr("kubernetes_secret_data.test", "data.one", "changed"),
+					testAccCheckKubernetesObjectData("Secret", "default", name, map[string]string{
+						"existing": "untouched",
+						"one":      "changed",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesSecretDataConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_secret" "test" {
+	metadata {
+		name = "%s"
+	}
+	data {
+		existing = "untouched"
+	}
+
+	lifecycle {
+		ignore_changes = ["data"]
+	}
+}
+
+resource "kubernetes_secret_data" "test" {
+	name = "${kubernetes_secret.test.metadata.0.name}"
+	data {
+		one = "first"
+		two = "second"
+	}
+}
+`, name)
+}
+
+func testAccKubernetesSecretDataConfig_modified(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_secret" "test" {
+	metadata {
+		name = "%s"
+	}
+	data {
+		existing = "untouched"
+	}
+
+	lifecycle {
+		ignore_changes = ["data"]
+	}
+}
+
+resource "kubernetes_secret_data" "test" {
+	name = "${kubernetes_secret.test.metadata.0.name}"
+	data {
+		one = "changed"
+	}
+}
+`, name)
+}
@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	pkgApi "k8s.io/apimachinery/pkg/types"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	api "k8s.io/kubernetes/pkg/api/v1"
 	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
 )
@@ -24,6 +26,10 @@ func resourceKubernetesService() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"metadata": namespacedMetadataSchema("service", true),
@@ -34,6 +40,12 @@ func resourceKubernetesService() *schema.Resource {
 				MaxItems:    1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"allocate_load_balancer_node_ports": {
+							Type:        schema.TypeBool,
+							Description: "Only applies to `type = LoadBalancer`. Whether node ports are allocated for the load balancer. Load balancers routing traffic directly to pods don't need them. Defaults to `true`. Requires Kubernetes 1.20+.",
+							Optional:    true,
+							Default:     true,
+						},
 						"cluster_ip": {
 							Type:        schema.TypeString,
 							Description: "The IP address of the service. It is usually assigned randomly by the master. If an address is specified manually and is not in use by others, it will be allocated to the service; otherwise, creation of the service will fail. `None` can be specified for headless services when proxying is not required. Ignored if type is `ExternalName`. More info: http://kubernetes.io/docs/user-guide/services#virtual-ips-and-service-proxies",
@@ -41,6 +53,12 @@ func resourceKubernetesService() *schema.Resource {
 							ForceNew:    true,
 							Computed:    true,
 						},
+						"cluster_ips": {
+							Type:        schema.TypeList,
+							Description: "The IP addresses of the service, one per IP family. The first one is `cluster_ip`.",
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
 						"external_ips": {
 							Type:        schema.TypeSet,
 							Description: "A list of IP addresses for which nodes in the cluster will also accept traffic for this service. These IPs are not managed by Kubernetes. The user is responsible for ensuring that traffic arrives at a node with this IP.  A common example is external load-balancers that are not part of the Kubernetes system.",
@@ -53,6 +71,49 @@ func resourceKubernetesService() *schema.Resource {
 							Description: "The external reference that kubedns or equivalent will return as a CNAME record for this service. No proxying will be involved. Must be a valid DNS name and requires `type` to be `ExternalName`.",
 							Optional:    true,
 						},
+						"external_traffic_policy": {
+							Type:         schema.TypeString,
+							Description:  "Whether external traffic is routed to node-local (`Local`) or cluster-wide (`Cluster`) endpoints. `Local` preserves the client source IP and avoids a second hop, but risks imbalanced traffic. Only applies to types `NodePort` and `LoadBalancer`, defaults to `Cluster` for them. Requires Kubernetes 1.7+. More info: https://kubernetes.io/docs/tasks/access-application-cluster/create-external-load-balancer/#preserving-the-client-source-ip",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"Cluster", "Local"}),
+						},
+						"health_check_node_port": {
+							Type:        schema.TypeInt,
+							Description: "Node port serving health checks of the load balancer, allocated when `type` is `LoadBalancer` and `external_traffic_policy` is `Local`.",
+							Computed:    true,
+						},
+						"internal_traffic_policy": {
+							Type:         schema.TypeString,
+							Description:  "Whether traffic from within the cluster is routed to node-local (`Local`) or cluster-wide (`Cluster`) endpoints. With `Local`, traffic is dropped on nodes without ready endpoints. Defaults to `Cluster`. Requires Kubernetes 1.21+.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"Cluster", "Local"}),
+						},
+						"ip_families": {
+							Type:        schema.TypeList,
+							Description: "IP families (`IPv4`, `IPv6`) of the service, primary family first. Defaulted by the server according to `ip_family_policy` and the cluster's configuration. The primary family can't be changed. Requires Kubernetes 1.20+.",
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    2,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validateAttributeValueIsIn([]string{"IPv4", "IPv6"}),
+							},
+						},
+						"ip_family_policy": {
+							Type:         schema.TypeString,
+							Description:  "Dual-stack-ness of the service, one of `SingleStack`, `PreferDualStack` or `RequireDualStack`. Defaults to `SingleStack`. Requires Kubernetes 1.20+.",
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"SingleStack", "PreferDualStack", "RequireDualStack"}),
+						},
+						"load_balancer_class": {
+							Type:        schema.TypeString,
+							Description: "Only applies to `type = LoadBalancer`. Class of the load balancer implementation to provision it with, e.g. MetalLB's, instead of the cloud provider's default one. Can't be changed. Requires Kubernetes 1.21+.",
+							Optional:    true,
+							ForceNew:    true,
+						},
 						"load_balancer_ip": {
 							Type:        schema.TypeString,
 							Description: "Only applies to `type = LoadBalancer`. LoadBalancer will get created with the IP specified in this field. This feature depends on whether the underlying cloud-provider supports specifying this field when a load balancer is created. This field will be ignored if the cloud-provider does not support the feature.",
@@ -78,10 +139,11 @@ func resourceKubernetesService() *schema.Resource {
 										Optional:    true,
 									},
 									"node_port": {
-										Type:        schema.TypeInt,
-										Description: "The port on each node on which this service is exposed when `type` is `NodePort` or `LoadBalancer`. Usually assigned by the system. If specified, it will be allocated to the service if unused or else creation of the service will fail. Default is to auto-allocate a port if the `type` of this service requires one. More info: http://kubernetes.io/docs/user-guide/services#type--nodeport",
-										Computed:    true,
-										Optional:    true,
+										Type:         schema.TypeInt,
+										Description:  "The port on each node on which this service is exposed when `type` is `NodePort` or `LoadBalancer`. Usually assigned by the system. If specified, it will be allocated to the service if unused or else creation of the service will fail. Must be a valid port number, which is checked on plan. The `node_port_range` of the provider and duplicates are only checked on apply, before the service is changed, and only within this service: node ports already allocated to it are only checked for duplicates, and a node port used by another service, even one in the same plan, is only rejected by the API server. Default is to auto-allocate a port if the `type` of this service requires one. More info: http://kubernetes.io/docs/user-guide/services#type--nodeport",
+										Computed:     true,
+										Optional:     true,
+										ValidateFunc: validatePortNum,
 									},
 									"port": {
 										Type:        schema.TypeInt,
@@ -114,6 +176,35 @@ func resourceKubernetesService() *schema.Resource {
 							Optional:    true,
 							Default:     "None",
 						},
+						"session_affinity_config": {
+							Type:        schema.TypeList,
+							Description: "Configuration of session affinity. Defaulted by the server when `session_affinity` is `ClientIP`. Requires Kubernetes 1.8+.",
+							Optional:    true,
+							Computed:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"client_ip": {
+										Type:        schema.TypeList,
+										Description: "Configuration of `ClientIP` session affinity.",
+										Optional:    true,
+										Computed:    true,
+										MaxItems:    1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"timeout_seconds": {
+													Type:         schema.TypeInt,
+													Description:  "Seconds a client sticks to the same pod, at most 86400 (1 day). Defaults to 10800 (3 hours).",
+													Optional:     true,
+													Computed:     true,
+													ValidateFunc: validateDuration(time.Second, 24*time.Hour),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
 						"type": {
 							Type:        schema.TypeString,
 							Description: "Determines how the service is exposed. Defaults to `ClusterIP`. Valid options are `ExternalName`, `ClusterIP`, `NodePort`, and `LoadBalancer`. `ExternalName` maps to the specified `external_name`. More info: http://kubernetes.io/docs/user-guide/services#overview",
@@ -123,6 +214,12 @@ func resourceKubernetesService() *schema.Resource {
 					},
 				},
 			},
+			"wait_for_load_balancer": {
+				Type:        schema.TypeBool,
+				Description: "Wait for the load balancer to be assigned an IP or hostname when `type` is `LoadBalancer`, so that `load_balancer_ingress` is known once the service is created. Bounded by the create and update timeouts.",
+				Optional:    true,
+				Default:     true,
+			},
 			"load_balancer_ingress": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -147,44 +244,26 @@ func resourceKubernetesServiceCreate(d *schema.ResourceData, meta interface{}) e
 	conn := meta.(*kubernetes.Clientset)
 
 	metadata := expandMetadata(d.Get("metadata").([]interface{}))
-	svc := api.Service{
+	svc := serviceWithNewerSpec{
 		ObjectMeta: metadata,
 		Spec:       expandServiceSpec(d.Get("spec").([]interface{})),
 	}
+	err := validateServiceNodePorts(svc.Spec.ServiceSpec, nil, getProviderOptions(meta).serviceNodePortRange())
+	if err != nil {
+		return err
+	}
 	log.Printf("[INFO] Creating new service: %#v", svc)
-	out, err := conn.CoreV1().Services(metadata.Namespace).Create(&svc)
+	out, err := createService(conn, &svc)
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Submitted new service: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
-	if out.Spec.Type == api.ServiceTypeLoadBalancer {
-		log.Printf("[DEBUG] Waiting for load balancer to assign IP/hostname")
-
-		err = resource.Retry(10*time.Minute, func() *resource.RetryError {
-			svc, err := conn.CoreV1().Services(out.Namespace).Get(out.Name, meta_v1.GetOptions{})
-			if err != nil {
-				log.Printf("[DEBUG] Received error: %#v", err)
-				return resource.NonRetryableError(err)
-			}
-
-			lbIngress := svc.Status.LoadBalancer.Ingress
-
-			log.Printf("[INFO] Received service status: %#v", svc.Status)
-			if len(lbIngress) > 0 {
-				return nil
-			}
-
-			return resource.RetryableError(fmt.Errorf(
-				"Waiting for service %q to assign IP/hostname for a load balancer", d.Id()))
-		})
+	if out.Spec.Type == api.ServiceTypeLoadBalancer && d.Get("wait_for_load_balancer").(bool) {
+		err = waitForLoadBalancerIngress(conn, out.ObjectMeta, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
-			lastWarnings, wErr := getLastWarningsForObject(conn, out.ObjectMeta, "Service", 3)
-			if wErr != nil {
-				return wErr
-			}
-			return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+			return err
 		}
 	}
 
@@ -200,7 +279,7 @@ func resourceKubernetesServiceRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	log.Printf("[INFO] Reading service %s", name)
-	svc, err := conn.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
+	svc, err := getService(conn, namespace, name)
 	if err != nil {
 		log.Printf("[DEBUG] Received error: %#v", err)
 		return err
@@ -236,6 +315,12 @@ func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) e
 
 	ops := patchMetadata("metadata.0.", "/metadata/", d)
 	if d.HasChange("spec") {
+		oldSpec, newSpec := d.GetChange("spec")
+		err = validateServiceNodePorts(expandServiceSpec(newSpec.([]interface{})).ServiceSpec, expandServiceSpec(oldSpec.([]interface{})).Ports,
+			getProviderOptions(meta).serviceNodePortRange())
+		if err != nil {
+			return err
+		}
 		diffOps := patchServiceSpec("spec.0.", "/spec/", d)
 		ops = append(ops, diffOps...)
 	}
@@ -246,11 +331,19 @@ func resourceKubernetesServiceUpdate(d *schema.ResourceData, meta interface{}) e
 	log.Printf("[INFO] Updating service %q: %v", name, string(data))
 	out, err := conn.CoreV1().Services(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update service: %s", err)
+		return wrapError(err, "Failed to update service")
 	}
 	log.Printf("[INFO] Submitted updated service: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
+	// A load balancer is only provisioned once the type changes to LoadBalancer
+	if d.HasChange("spec.0.type") && out.Spec.Type == api.ServiceTypeLoadBalancer && d.Get("wait_for_load_balancer").(bool) {
+		err = waitForLoadBalancerIngress(conn, out.ObjectMeta, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesServiceRead(d, meta)
 }
 
@@ -292,3 +385,125 @@ func resourceKubernetesServiceExists(d *schema.ResourceData, meta interface{}) (
 	}
 	return true, err
 }
+
+// defaultNodePortRange is the default --service-node-port-range of the API server
+var defaultNodePortRange = utilnet.PortRange{Base: 30000, Size: 2768}
+
+// validateServiceNodePorts catches node port requests the API server
+// would reject before anything is created. Ports already allocated
+// to the service are only checked for duplicates.
+// It needs the provider's node port range and the whole list of ports,
+// neither of which a ValidateFunc gets, so it can only run on apply.
+// Node ports used by other services are left to the API server.
+func validateServiceNodePorts(spec api.ServiceSpec, allocated []api.ServicePort, nodePortRange utilnet.PortRange) error {
+	seen := make(map[string]bool, len(spec.Ports))
+	for _, p := range spec.Ports {
+		if p.NodePort == 0 {
+			continue
+		}
+		key := fmt.Sprintf("%d/%s", p.NodePort, p.Protocol)
+		if seen[key] {
+			return fmt.Errorf("node_port %d (%s) is requested more than once", p.NodePort, p.Protocol)
+		}
+		seen[key] = true
+
+		if isNodePortAllocated(p.NodePort, allocated) {
+			continue
+		}
+		if spec.Type != api.ServiceTypeNodePort && spec.Type != api.ServiceTypeLoadBalancer {
+			return fmt.Errorf("node_port %d may only be set when type is NodePort or LoadBalancer", p.NodePort)
+		}
+		if !nodePortRange.Contains(int(p.NodePort)) {
+			return fmt.Errorf("node_port %d is outside of the node port range %s", p.NodePort, nodePortRange.String())
+		}
+	}
+	return nil
+}
+
+func isNodePortAllocated(nodePort int32, allocated []api.ServicePort) bool {
+	for _, p := range allocated {
+		if p.NodePort == nodePort {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceWithNewerSpec is a service including spec fields
+// which the vendored API types predate
+type serviceWithNewerSpec struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec               serviceSpec       `json:"spec,omitempty"`
+	Status             api.ServiceStatus `json:"status,omitempty"`
+}
+
+func createService(conn *kubernetes.Clientset, svc *serviceWithNewerSpec) (*serviceWithNewerSpec, error) {
+	in := *svc
+	in.APIVersion = "v1"
+	in.Kind = "Service"
+	body, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := conn.CoreV1().RESTClient().Post().
+		Namespace(svc.Namespace).
+		Resource("services").
+		Body(body).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var out serviceWithNewerSpec
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode service: %s", err)
+	}
+	return &out, nil
+}
+
+func getService(conn *kubernetes.Clientset, namespace, name string) (*serviceWithNewerSpec, error) {
+	raw, err := conn.CoreV1().RESTClient().Get().
+		Namespace(namespace).
+		Resource("services").
+		Name(name).
+		Do().
+		Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	var out serviceWithNewerSpec
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode service: %s", err)
+	}
+	return &out, nil
+}
+
+func waitForLoadBalancerIngress(conn *kubernetes.Clientset, metadata meta_v1.ObjectMeta, timeout time.Duration) error {
+	log.Printf("[DEBUG] Waiting for load balancer to assign IP/hostname")
+
+	return waitWithEvents(conn, metadata, "Service", func() error {
+		return resource.Retry(timeout, func() *resource.RetryError {
+			svc, err := conn.CoreV1().Services(metadata.Namespace).Get(metadata.Name, meta_v1.GetOptions{})
+			if err != nil {
+				log.Printf("[DEBUG] Received error: %#v", err)
+				return resource.NonRetryableError(err)
+			}
+
+			lbIngress := svc.Status.LoadBalancer.Ingress
+
+			log.Printf("[INFO] Received service status: %#v", svc.Status)
+			if len(lbIngress) > 0 {
+				return nil
+			}
+
+			return resource.RetryableError(fmt.Errorf(
+				"Waiting for service %q to assign IP/hostname for a load balancer", buildId(metadata)))
+		})
+	})
+}
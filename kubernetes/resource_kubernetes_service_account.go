@@ -187,7 +187,7 @@ func resourceKubernetesServiceAccountUpdate(d *schema.ResourceData, meta interfa
 	log.Printf("[INFO] Updating service account %q: %v", name, string(data))
 	out, err := conn.CoreV1().ServiceAccounts(namespace).Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update service account: %s", err)
+		return wrapError(err, "Failed to update service account")
 	}
 	log.Printf("[INFO] Submitted updated service account: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
@@ -0,0 +1,177 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// tokenRequest is an authentication.k8s.io/v1 TokenRequest,
+// the vendored client predates the API
+type tokenRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Spec       struct {
+		Audiences         []string `json:"audiences,omitempty"`
+		ExpirationSeconds *int64   `json:"expirationSeconds,omitempty"`
+	} `json:"spec"`
+	Status struct {
+		Token               string      `json:"token"`
+		ExpirationTimestamp metav1.Time `json:"expirationTimestamp"`
+	} `json:"status"`
+}
+
+func resourceKubernetesServiceAccountToken() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceKubernetesServiceAccountTokenCreate,
+		Read:   resourceKubernetesServiceAccountTokenRead,
+		Delete: resourceKubernetesServiceAccountTokenDelete,
+
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Description: "Namespace of the service account.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "default",
+			},
+			"service_account_name": {
+				Type:        schema.TypeString,
+				Description: "Name of the service account to issue the token for.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"audiences": {
+				Type:        schema.TypeList,
+				Description: "Intended audiences of the token. Defaults to the audiences of the API server.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"expiration_seconds": {
+				Type:         schema.TypeInt,
+				Description:  "Requested duration of validity of the token. The API server may return a token with a shorter validity. Must be at least 600.",
+				Optional:     true,
+				ForceNew:     true,
+				Default:      3600,
+				ValidateFunc: validateTokenExpirationSeconds,
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Description: "The issued token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expiration_timestamp": {
+				Type:        schema.TypeString,
+				Description: "When the token expires (RFC 3339). A new token is issued on the first refresh after it expired.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesServiceAccountTokenCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace := d.Get("namespace").(string)
+	name := d.Get("service_account_name").(string)
+
+	audiences := expandStringSlice(d.Get("audiences").([]interface{}))
+	expiration := int64(d.Get("expiration_seconds").(int))
+
+	log.Printf("[INFO] Requesting token for service account %s/%s", namespace, name)
+	out, err := requestServiceAccountToken(conn, namespace, name, audiences, expiration)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Received token expiring at %s", out.Status.ExpirationTimestamp)
+
+	d.SetId(buildId(metav1.ObjectMeta{Namespace: namespace, Name: name}))
+	d.Set("token", out.Status.Token)
+	d.Set("expiration_timestamp", out.Status.ExpirationTimestamp.UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+func resourceKubernetesServiceAccountTokenRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*kubernetes.Clientset)
+
+	namespace, name, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// Tokens are invalidated together with their service account
+	log.Printf("[INFO] Checking service account %s", name)
+	_, err = conn.CoreV1().ServiceAccounts(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			log.Printf("[INFO] Service account %s/%s is gone", namespace, name)
+			d.SetId("")
+			return nil
+		}
+		log.Printf("[DEBUG] Received error: %#v", err)
+		return err
+	}
+
+	// Tokens can't be read back, only check whether ours is still valid
+	expiration, err := time.Parse(time.RFC3339, d.Get("expiration_timestamp").(string))
+	if err != nil {
+		return err
+	}
+	if time.Now().After(expiration) {
+		log.Printf("[INFO] Token for service account %s expired at %s", d.Id(), expiration)
+		d.SetId("")
+	}
+	return nil
+}
+
+func resourceKubernetesServiceAccountTokenDelete(d *schema.ResourceData, meta interface{}) error {
+	// Bound tokens expire on their own and can't be revoked
+	d.SetId("")
+	return nil
+}
+
+// requestServiceAccountToken issues a new token for the service account
+// through the serviceaccounts/token subresource
+func requestServiceAccountToken(conn *kubernetes.Clientset, namespace, name string, audiences []string, expirationSeconds int64) (*tokenRequest, error) {
+	req := tokenRequest{
+		APIVersion: "authentication.k8s.io/v1",
+		Kind:       "TokenRequest",
+	}
+	req.Spec.Audiences = audiences
+	req.Spec.ExpirationSeconds = &expirationSeconds
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := conn.CoreV1().RESTClient().Post().
+		Namespace(namespace).
+		Resource("serviceaccounts").
+		Name(name).
+		SubResource("token").
+		Body(body).
+		Do().
+		Raw()
+	if err != nil {
+		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+			return nil, fmt.Errorf("Service account %s/%s not found, or the cluster doesn't support the TokenRequest API (Kubernetes 1.12+): %s", namespace, name, err)
+		}
+		return nil, err
+	}
+
+	var out tokenRequest
+	err = json.Unmarshal(raw, &out)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode token request: %s", err)
+	}
+	return &out, nil
+}
@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestRequestServiceAccountToken(t *testing.T) {
+	var sent tokenRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method != "POST" || r.URL.Path != "/api/v1/namespaces/apps/serviceaccounts/deployer/token" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(body, &sent)
+		fmt.Fprint(w, `{"kind":"TokenRequest","apiVersion":"authentication.k8s.io/v1","spec":{},"status":{"token":"abc.def.ghi","expirationTimestamp":"2030-01-02T03:04:05Z"}}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := requestServiceAccountToken(conn, "apps", "deployer", []string{"vault"}, 1200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Kind != "TokenRequest" || len(sent.Spec.Audiences) != 1 || sent.Spec.Audiences[0] != "vault" ||
+		sent.Spec.ExpirationSeconds == nil || *sent.Spec.ExpirationSeconds != 1200 {
+		t.Fatalf("Unexpected token request: %#v", sent)
+	}
+	if out.Status.Token != "abc.def.ghi" || out.Status.ExpirationTimestamp.UTC().Year() != 2030 {
+		t.Fatalf("Unexpected token response: %#v", out)
+	}
+
+	_, err = requestServiceAccountToken(conn, "apps", "missing", nil, 1200)
+	if err == nil || !strings.Contains(err.Error(), "TokenRequest API") {
+		t.Fatalf("Expected missing service account to fail, got %v", err)
+	}
+}
+
+func TestAccKubernetesServiceAccountToken_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubernetesServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceAccountTokenConfig_basic(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubernetes_service_account_token.test", "namespace", "default"),
+					resource.TestCheckResourceAttr("kubernetes_service_account_token.test", "service_account_name", name),
+					resource.TestCheckResourceAttr("kubernetes_service_account_token.test", "expiration_seconds", "600"),
+					resource.TestCheckResourceAttrSet("kubernetes_service_account_token.test", "token"),
+					resource.TestMatchResourceAttr("kubernetes_service_account_token.test", "expiration_timestamp", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccKubernetesServiceAccountTokenConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service_account" "test" {
+	metadata {
+		name = "%s"
+	}
+}
+
+resource "kubernetes_service_account_token" "test" {
+	service_account_name = "${kubernetes_service_account.test.metadata.0.name}"
+	expiration_seconds = 600
+}
+`, name)
+}
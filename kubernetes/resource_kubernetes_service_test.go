@@ -1,16 +1,24 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	restclient "k8s.io/client-go/rest"
 	api "k8s.io/kubernetes/pkg/api/v1"
 	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
 )
@@ -119,6 +127,8 @@ func TestAccKubernetesService_loadBalancer(t *testing.T) {
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.selector.App", "MyApp"),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity", "ClientIP"),
 					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.type", "LoadBalancer"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "wait_for_load_balancer", "true"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "load_balancer_ingress.#", "1"),
 					testAccCheckServicePorts(&conf, []api.ServicePort{
 						{
 							Port:       int32(8888),
@@ -222,6 +232,185 @@ func TestAccKubernetesService_nodePort(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesService_externalTrafficPolicy(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_externalTrafficPolicy(name, "Local"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.type", "NodePort"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.external_traffic_policy", "Local"),
+				),
+			},
+			{
+				Config: testAccKubernetesServiceConfig_externalTrafficPolicy(name, "Cluster"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.external_traffic_policy", "Cluster"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.health_check_node_port", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesService_sessionAffinityConfig(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_sessionAffinityConfig(name, 3600),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity", "ClientIP"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity_config.0.client_ip.0.timeout_seconds", "3600"),
+				),
+			},
+			{
+				Config: testAccKubernetesServiceConfig_sessionAffinityConfig(name, 600),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.session_affinity_config.0.client_ip.0.timeout_seconds", "600"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesService_ipFamilies(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_ipFamilies(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.ip_family_policy", "SingleStack"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.ip_families.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.ip_families.0", "IPv4"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.cluster_ips.#", "1"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.internal_traffic_policy", "Local"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesService_loadBalancerClass(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_loadBalancerClass(name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.type", "LoadBalancer"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.load_balancer_class", "example.com/no-such-implementation"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "load_balancer_ingress.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKubernetesService_allocateLoadBalancerNodePorts(t *testing.T) {
+	var conf api.Service
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_service.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesServiceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesServiceConfig_allocateLoadBalancerNodePorts(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.allocate_load_balancer_node_ports", "false"),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.port.0.node_port", "0"),
+				),
+			},
+			{
+				Config: testAccKubernetesServiceConfig_allocateLoadBalancerNodePorts(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesServiceExists("kubernetes_service.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_service.test", "spec.0.allocate_load_balancer_node_ports", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestCreateGetService_newerSpec(t *testing.T) {
+	var created map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "POST" && r.URL.Path == "/api/v1/namespaces/default/services" {
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &created)
+		}
+		fmt.Fprint(w, `{"kind":"Service","apiVersion":"v1","metadata":{"name":"test","namespace":"default"},`+
+			`"spec":{"type":"LoadBalancer","ports":[{"port":80,"nodePort":30080}],"externalTrafficPolicy":"Local","healthCheckNodePort":31000}}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := &serviceWithNewerSpec{ObjectMeta: meta_v1.ObjectMeta{Name: "test", Namespace: "default"}}
+	svc.Spec.Type = api.ServiceTypeLoadBalancer
+	svc.Spec.ExternalTrafficPolicy = "Local"
+	_, err = createService(conn, svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, ok := created["spec"].(map[string]interface{})
+	if !ok || spec["type"] != "LoadBalancer" || spec["externalTrafficPolicy"] != "Local" {
+		t.Fatalf("Unexpected request: %#v", created)
+	}
+	if _, ok := spec["healthCheckNodePort"]; ok {
+		t.Fatalf("Expected unset health check node port to be omitted: %#v", spec)
+	}
+
+	out, err := getService(conn, "default", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "test" || out.Spec.Ports[0].NodePort != 30080 ||
+		out.Spec.ExternalTrafficPolicy != "Local" || out.Spec.HealthCheckNodePort != 31000 {
+		t.Fatalf("Unexpected service: %#v", out)
+	}
+}
+
 func TestAccKubernetesService_noTargetPort(t *testing.T) {
 	var conf api.Service
 	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
@@ -320,9 +509,10 @@ func TestAccKubernetesService_importBasic(t *testing.T) {
 			},
 
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_load_balancer"},
 			},
 		},
 	})
@@ -372,14 +562,153 @@ func TestAccKubernetesService_importGeneratedName(t *testing.T) {
 			},
 
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_load_balancer"},
 			},
 		},
 	})
 }
 
+func TestValidateServiceNodePorts(t *testing.T) {
+	nodePort := func(port int32, protocol api.Protocol) api.ServicePort {
+		return api.ServicePort{NodePort: port, Protocol: protocol}
+	}
+	cases := []struct {
+		Spec      api.ServiceSpec
+		Allocated []api.ServicePort
+		Valid     bool
+	}{
+		{api.ServiceSpec{Type: api.ServiceTypeClusterIP, Ports: []api.ServicePort{nodePort(0, api.ProtocolTCP)}}, nil, true},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(30080, api.ProtocolTCP)}}, nil, true},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(30080, api.ProtocolTCP), nodePort(30080, api.ProtocolUDP)}}, nil, true},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(30080, api.ProtocolTCP), nodePort(30080, api.ProtocolTCP)}}, nil, false},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(8080, api.ProtocolTCP)}}, nil, false},
+		{api.ServiceSpec{Type: api.ServiceTypeLoadBalancer, Ports: []api.ServicePort{nodePort(32768, api.ProtocolTCP)}}, nil, false},
+		{api.ServiceSpec{Type: api.ServiceTypeClusterIP, Ports: []api.ServicePort{nodePort(30080, api.ProtocolTCP)}}, nil, false},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(8080, api.ProtocolTCP)}}, []api.ServicePort{nodePort(8080, api.ProtocolTCP)}, true},
+		// Updates, only ports which aren't allocated yet are range checked
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(8080, api.ProtocolTCP), nodePort(30443, api.ProtocolTCP)}}, []api.ServicePort{nodePort(8080, api.ProtocolTCP)}, true},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(8080, api.ProtocolTCP), nodePort(8443, api.ProtocolTCP)}}, []api.ServicePort{nodePort(8080, api.ProtocolTCP)}, false},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(8080, api.ProtocolTCP), nodePort(8080, api.ProtocolTCP)}}, []api.ServicePort{nodePort(8080, api.ProtocolTCP)}, false},
+		{api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{nodePort(30080, api.ProtocolTCP)}}, []api.ServicePort{nodePort(8080, api.ProtocolTCP)}, true},
+	}
+	for i, tc := range cases {
+		err := validateServiceNodePorts(tc.Spec, tc.Allocated, defaultNodePortRange)
+		if tc.Valid && err != nil {
+			t.Fatalf("%d: expected no error, got: %s", i, err)
+		}
+		if !tc.Valid && err == nil {
+			t.Fatalf("%d: expected an error", i)
+		}
+	}
+}
+
+func TestUpdateService_nodePorts(t *testing.T) {
+	// The service keeps node port 8080, allocated from an older range
+	requests := make([]string, 0)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Service","apiVersion":"v1","metadata":{"name":"web","namespace":"default"},`+
+			`"spec":{"type":"NodePort","clusterIP":"10.0.0.10","sessionAffinity":"None",`+
+			`"ports":[{"name":"http","protocol":"TCP","port":80,"targetPort":80,"nodePort":8080}]}}`)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		HTTPSNodePort int
+		Error         string
+	}{
+		{30443, ""},
+		{8443, "node_port 8443 is outside of the node port range 30000-32767"},
+		{8080, "node_port 8080 (TCP) is requested more than once"},
+	}
+	for _, tc := range cases {
+		requests = requests[:0]
+		r := resourceKubernetesService()
+		state, err := r.Refresh(&terraform.InstanceState{ID: "default/web"}, conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state == nil || state.Attributes["spec.0.port.0.node_port"] != "8080" {
+			t.Fatalf("Expected the service to be read, got %#v", state)
+		}
+
+		raw, err := config.NewRawConfig(map[string]interface{}{
+			"metadata": []interface{}{
+				map[string]interface{}{"name": "web", "namespace": "default"},
+			},
+			"spec": []interface{}{
+				map[string]interface{}{
+					"type": "NodePort",
+					"port": []interface{}{
+						map[string]interface{}{"name": "http", "port": 80, "target_port": "80", "node_port": 8080},
+						map[string]interface{}{"name": "https", "port": 443, "target_port": "443", "node_port": tc.HTTPSNodePort},
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		diff, err := r.Diff(state, terraform.NewResourceConfig(raw))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff == nil || diff.RequiresNew() {
+			t.Fatalf("Expected an update, got %#v", diff)
+		}
+		_, err = r.Apply(state, diff, conn)
+
+		patched := false
+		for _, req := range requests {
+			patched = patched || strings.HasPrefix(req, "PATCH ")
+		}
+		if tc.Error == "" {
+			if err != nil || !patched {
+				t.Fatalf("%d: expected the service to be patched, got %v (%q)", tc.HTTPSNodePort, err, requests)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.Error) {
+			t.Fatalf("%d: expected %q, got %v", tc.HTTPSNodePort, tc.Error, err)
+		}
+		if patched {
+			t.Fatalf("%d: expected the service not to be changed, got %q", tc.HTTPSNodePort, requests)
+		}
+	}
+}
+
+func TestServiceNodePortRange_perProvider(t *testing.T) {
+	custom, err := kubernetes.NewForConfig(&restclient.Config{Host: "http://localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setProviderOptions(custom, providerOptions{nodePortRange: utilnet.PortRange{Base: 8000, Size: 1001}})
+	defer setProviderOptions(custom, providerOptions{})
+	other, err := kubernetes.NewForConfig(&restclient.Config{Host: "http://localhost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	setProviderOptions(other, providerOptions{})
+	defer setProviderOptions(other, providerOptions{})
+
+	spec := api.ServiceSpec{Type: api.ServiceTypeNodePort, Ports: []api.ServicePort{{NodePort: 8080, Protocol: api.ProtocolTCP}}}
+	if err := validateServiceNodePorts(spec, nil, getProviderOptions(custom).serviceNodePortRange()); err != nil {
+		t.Fatalf("Expected node port in the configured range to be valid, got %s", err)
+	}
+	if err := validateServiceNodePorts(spec, nil, getProviderOptions(other).serviceNodePortRange()); err == nil {
+		t.Fatal("Expected another provider to keep the default node port range")
+	}
+}
+
 func testAccCheckServicePorts(svc *api.Service, expected []api.ServicePort) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(svc.Spec.Ports) == 0 {
@@ -573,6 +902,114 @@ resource "kubernetes_service" "test" {
 }`, name, name)
 }
 
+func testAccKubernetesServiceConfig_externalTrafficPolicy(name, policy string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		selector {
+			App = "MyApp"
+		}
+		port {
+			port = 8080
+			target_port = 80
+		}
+		type = "NodePort"
+		external_traffic_policy = "%s"
+	}
+}`, name, policy)
+}
+
+func testAccKubernetesServiceConfig_sessionAffinityConfig(name string, timeout int) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		selector {
+			App = "MyApp"
+		}
+		port {
+			port = 8080
+			target_port = 80
+		}
+		session_affinity = "ClientIP"
+		session_affinity_config {
+			client_ip {
+				timeout_seconds = %d
+			}
+		}
+	}
+}`, name, timeout)
+}
+
+func testAccKubernetesServiceConfig_ipFamilies(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		selector {
+			App = "MyApp"
+		}
+		port {
+			port = 8080
+			target_port = 80
+		}
+		ip_family_policy = "SingleStack"
+		ip_families = ["IPv4"]
+		internal_traffic_policy = "Local"
+	}
+}`, name)
+}
+
+func testAccKubernetesServiceConfig_loadBalancerClass(name string) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		selector {
+			App = "MyApp"
+		}
+		port {
+			port = 8080
+			target_port = 80
+		}
+		type = "LoadBalancer"
+		load_balancer_class = "example.com/no-such-implementation"
+	}
+	# No controller implements the class, so there won't be an address
+	wait_for_load_balancer = false
+}`, name)
+}
+
+func testAccKubernetesServiceConfig_allocateLoadBalancerNodePorts(name string, allocate bool) string {
+	return fmt.Sprintf(`
+resource "kubernetes_service" "test" {
+	metadata {
+		name = "%s"
+	}
+	spec {
+		selector {
+			App = "MyApp"
+		}
+		port {
+			port = 8080
+			target_port = 80
+		}
+		type = "LoadBalancer"
+		allocate_load_balancer_node_ports = %t
+	}
+	wait_for_load_balancer = false
+}`, name, allocate)
+}
+
 func testAccKubernetesServiceConfig_noTargetPort(name string) string {
 	return fmt.Sprintf(`
 resource "kubernetes_service" "test" {
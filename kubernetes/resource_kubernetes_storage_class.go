@@ -1,6 +1,7 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -25,6 +26,18 @@ func resourceKubernetesStorageClass() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"metadata": metadataSchema("storage class", true),
+			"is_default_class": {
+				Type:        schema.TypeBool,
+				Description: "Marks this storage class as the default for claims that don't request any particular class.",
+				Optional:    true,
+				Default:     false,
+			},
+			"replace_existing_default": {
+				Type:        schema.TypeBool,
+				Description: "Removes the default marker from any other storage class when `is_default_class` is set.",
+				Optional:    true,
+				Default:     false,
+			},
 			"parameters": {
 				Type:        schema.TypeMap,
 				Description: "The parameters for the provisioner that should create volumes of this storage class",
@@ -54,6 +67,19 @@ func resourceKubernetesStorageClassCreate(d *schema.ResourceData, meta interface
 		storageClass.Parameters = expandStringMap(v.(map[string]interface{}))
 	}
 
+	if d.Get("is_default_class").(bool) {
+		if d.Get("replace_existing_default").(bool) {
+			err := clearDefaultStorageClasses(conn, metadata.Name)
+			if err != nil {
+				return err
+			}
+		}
+		if storageClass.Annotations == nil {
+			storageClass.Annotations = make(map[string]string)
+		}
+		storageClass.Annotations[storageClassDefaultAnnotation] = "true"
+	}
+
 	log.Printf("[INFO] Creating new storage class: %#v", storageClass)
 	out, err := conn.StorageV1().StorageClasses().Create(&storageClass)
 	if err != nil {
@@ -80,6 +106,7 @@ func resourceKubernetesStorageClassRead(d *schema.ResourceData, meta interface{}
 	if err != nil {
 		return err
 	}
+	d.Set("is_default_class", isDefaultStorageClass(storageClass.ObjectMeta))
 	d.Set("parameters", storageClass.Parameters)
 	d.Set("storage_provisioner", storageClass.Provisioner)
 
@@ -98,11 +125,25 @@ func resourceKubernetesStorageClassUpdate(d *schema.ResourceData, meta interface
 	log.Printf("[INFO] Updating storage class %q: %v", name, string(data))
 	out, err := conn.StorageV1().StorageClasses().Patch(name, pkgApi.JSONPatchType, data)
 	if err != nil {
-		return fmt.Errorf("Failed to update storage class: %s", err)
+		return wrapError(err, "Failed to update storage class")
 	}
 	log.Printf("[INFO] Submitted updated storage class: %#v", out)
 	d.SetId(buildId(out.ObjectMeta))
 
+	if d.HasChange("is_default_class") {
+		isDefault := d.Get("is_default_class").(bool)
+		if isDefault && d.Get("replace_existing_default").(bool) {
+			err := clearDefaultStorageClasses(conn, name)
+			if err != nil {
+				return err
+			}
+		}
+		err := patchStorageClassDefault(conn, name, isDefault)
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceKubernetesStorageClassRead(d, meta)
 }
 
@@ -136,3 +177,58 @@ func resourceKubernetesStorageClassExists(d *schema.ResourceData, meta interface
 	}
 	return true, err
 }
+
+const (
+	storageClassDefaultAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	storageClassBetaDefaultAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+func isDefaultStorageClass(meta metav1.ObjectMeta) bool {
+	return meta.Annotations[storageClassDefaultAnnotation] == "true" ||
+		meta.Annotations[storageClassBetaDefaultAnnotation] == "true"
+}
+
+// patchStorageClassDefault sets or removes the default class annotation.
+// A merge patch is used as the annotation map may not exist on the object yet.
+func patchStorageClassDefault(conn *kubernetes.Clientset, name string, isDefault bool) error {
+	annotations := map[string]interface{}{
+		storageClassDefaultAnnotation:     nil,
+		storageClassBetaDefaultAnnotation: nil,
+	}
+	if isDefault {
+		annotations[storageClassDefaultAnnotation] = "true"
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to marshal update operations: %s", err)
+	}
+
+	log.Printf("[INFO] Updating default class annotation of storage class %q: %v", name, string(data))
+	_, err = conn.StorageV1().StorageClasses().Patch(name, pkgApi.MergePatchType, data)
+	if err != nil {
+		return wrapError(err, "Failed to update storage class %q", name)
+	}
+	return nil
+}
+
+func clearDefaultStorageClasses(conn *kubernetes.Clientset, except string) error {
+	metas, err := listObjectMetas(conn, "StorageClass", "", "", 0)
+	if err != nil {
+		return err
+	}
+	for _, m := range metas {
+		if m.Name == except || !isDefaultStorageClass(m) {
+			continue
+		}
+		log.Printf("[INFO] Removing default class annotation from storage class %s", m.Name)
+		err = patchStorageClassDefault(conn, m.Name, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -168,6 +168,37 @@ func TestAccKubernetesStorageClass_importGeneratedName(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesStorageClass_defaultClass(t *testing.T) {
+	var conf api.StorageClass
+	name := fmt.Sprintf("tf-acc-test-%s", acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:      func() { testAccPreCheck(t) },
+		IDRefreshName: "kubernetes_storage_class.test",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckKubernetesStorageClassDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKubernetesStorageClassConfig_defaultClass(name, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStorageClassExists("kubernetes_storage_class.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "is_default_class", "true"),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "metadata.0.annotations.%", "0"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}),
+				),
+			},
+			{
+				Config: testAccKubernetesStorageClassConfig_defaultClass(name, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckKubernetesStorageClassExists("kubernetes_storage_class.test", &conf),
+					resource.TestCheckResourceAttr("kubernetes_storage_class.test", "is_default_class", "false"),
+					testAccCheckMetaAnnotations(&conf.ObjectMeta, map[string]string{}),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckStorageClassParameters(m *api.StorageClass, expected map[string]string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		if len(expected) == 0 && len(m.Parameters) == 0 {
@@ -282,3 +313,14 @@ resource "kubernetes_storage_class" "test" {
 	storage_provisioner = "kubernetes.io/gce-pd"
 }`, prefix)
 }
+
+func testAccKubernetesStorageClassConfig_defaultClass(name string, isDefault bool) string {
+	return fmt.Sprintf(`
+resource "kubernetes_storage_class" "test" {
+	metadata {
+		name = "%s"
+	}
+	storage_provisioner = "kubernetes.io/gce-pd"
+	is_default_class = %t
+}`, name, isDefault)
+}
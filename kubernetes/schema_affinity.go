@@ -0,0 +1,209 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func affinitySchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"node_affinity": {
+				Type:        schema.TypeList,
+				Description: "Node affinity scheduling rules for the pod.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"required_during_scheduling_ignored_during_execution": {
+							Type:        schema.TypeList,
+							Description: "If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a node label update), the system may or may not try to eventually evict the pod from its node.",
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"node_selector_term": {
+										Type:        schema.TypeList,
+										Description: "List of node selector terms. The terms are ORed.",
+										Required:    true,
+										Elem:        nodeSelectorTermSchema(),
+									},
+								},
+							},
+						},
+						"preferred_during_scheduling_ignored_during_execution": {
+							Type:        schema.TypeList,
+							Description: "The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights of the terms it matches.",
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"weight": {
+										Type:         schema.TypeInt,
+										Description:  "Weight associated with matching the corresponding node selector term, in the range 1-100.",
+										Required:     true,
+										ValidateFunc: validateSchedulingWeight,
+									},
+									"preference": {
+										Type:        schema.TypeList,
+										Description: "A node selector term, associated with the corresponding weight.",
+										Required:    true,
+										MaxItems:    1,
+										Elem:        nodeSelectorTermSchema(),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"pod_affinity": {
+				Type:        schema.TypeList,
+				Description: "Pod affinity scheduling rules, e.g. co-locate this pod in the same node, zone, etc. as some other pods.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        podAffinitySchema(),
+			},
+			"pod_anti_affinity": {
+				Type:        schema.TypeList,
+				Description: "Pod anti-affinity scheduling rules, e.g. avoid putting this pod in the same node, zone, etc. as some other pods.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        podAffinitySchema(),
+			},
+		},
+	}
+}
+
+func nodeSelectorTermSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"match_expressions": {
+				Type:        schema.TypeList,
+				Description: "A list of node selector requirements by node's labels. The requirements are ANDed.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Description: "The label key that the selector applies to.",
+							Required:    true,
+						},
+						"operator": {
+							Type:         schema.TypeString,
+							Description:  "A key's relationship to a set of values. Valid operators are `In`, `NotIn`, `Exists`, `DoesNotExist`, `Gt` and `Lt`.",
+							Required:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"In", "NotIn", "Exists", "DoesNotExist", "Gt", "Lt"}),
+						},
+						"values": {
+							Type:        schema.TypeSet,
+							Description: "An array of string values. If the operator is `In` or `NotIn`, the values array must be non-empty. If the operator is `Exists` or `DoesNotExist`, the values array must be empty. If the operator is `Gt` or `Lt`, the values array must have a single element, which will be interpreted as an integer.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func podAffinitySchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"required_during_scheduling_ignored_during_execution": {
+				Type:        schema.TypeList,
+				Description: "If the requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. All terms must be satisfied.",
+				Optional:    true,
+				Elem:        podAffinityTermSchema(),
+			},
+			"preferred_during_scheduling_ignored_during_execution": {
+				Type:        schema.TypeList,
+				Description: "The scheduler will prefer to schedule pods to nodes that satisfy the requirements specified by this field, but it may choose a node that violates one or more of them. The node that is most preferred is the one with the greatest sum of weights of the terms it matches.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"weight": {
+							Type:         schema.TypeInt,
+							Description:  "Weight associated with matching the corresponding pod affinity term, in the range 1-100.",
+							Required:     true,
+							ValidateFunc: validateSchedulingWeight,
+						},
+						"pod_affinity_term": {
+							Type:        schema.TypeList,
+							Description: "A pod affinity term, associated with the corresponding weight.",
+							Required:    true,
+							MaxItems:    1,
+							Elem:        podAffinityTermSchema(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func podAffinityTermSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"label_selector": {
+				Type:        schema.TypeList,
+				Description: "A label query over the set of pods the term applies to.",
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        podLabelSelectorSchema(),
+			},
+			"namespaces": {
+				Type:        schema.TypeSet,
+				Description: "Namespaces the label selector applies to. Defaults to the namespace of the pod.",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+			"topology_key": {
+				Type:        schema.TypeString,
+				Description: "The pod is co-located (affinity) or not co-located (anti-affinity) with the pods matching the label selector in the specified namespaces, where co-located is defined as running on a node whose value of the label with this key matches that of any node on which any of the selected pods is running, e.g. `kubernetes.io/hostname` or `topology.kubernetes.io/zone`.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// podLabelSelectorSchema is a label query over pods
+func podLabelSelectorSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"match_expressions": {
+				Type:        schema.TypeList,
+				Description: "A list of label selector requirements. The requirements are ANDed.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Description: "The label key that the selector applies to.",
+							Required:    true,
+						},
+						"operator": {
+							Type:         schema.TypeString,
+							Description:  "A key's relationship to a set of values. Valid operators are `In`, `NotIn`, `Exists` and `DoesNotExist`.",
+							Required:     true,
+							ValidateFunc: validateAttributeValueIsIn([]string{"In", "NotIn", "Exists", "DoesNotExist"}),
+						},
+						"values": {
+							Type:        schema.TypeSet,
+							Description: "An array of string values. If the operator is `In` or `NotIn`, the values array must be non-empty. If the operator is `Exists` or `DoesNotExist`, the values array must be empty.",
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+						},
+					},
+				},
+			},
+			"match_labels": {
+				Type:        schema.TypeMap,
+				Description: "A map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of `match_expressions`, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+				Optional:    true,
+			},
+		},
+	}
+}
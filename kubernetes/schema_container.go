@@ -1,6 +1,10 @@
 package kubernetes
 
-import "github.com/hashicorp/terraform/helper/schema"
+import (
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
 
 func handlerFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -113,6 +117,14 @@ func resourcesField() map[string]*schema.Schema {
 						ValidateFunc:     validateResourceQuantity,
 						DiffSuppressFunc: suppressEquivalentResourceQuantity,
 					},
+					"ephemeral_storage": {
+						Type:             schema.TypeString,
+						Description:      "Local ephemeral storage (container writable layer, logs and `empty_dir` volumes). Pods exceeding their limit are evicted by the kubelet.",
+						Optional:         true,
+						Computed:         true,
+						ValidateFunc:     validateResourceQuantity,
+						DiffSuppressFunc: suppressEquivalentResourceQuantity,
+					},
 				},
 			},
 		},
@@ -137,6 +149,14 @@ func resourcesField() map[string]*schema.Schema {
 						ValidateFunc:     validateResourceQuantity,
 						DiffSuppressFunc: suppressEquivalentResourceQuantity,
 					},
+					"ephemeral_storage": {
+						Type:             schema.TypeString,
+						Description:      "Local ephemeral storage (container writable layer, logs and `empty_dir` volumes). Pods exceeding their limit are evicted by the kubelet.",
+						Optional:         true,
+						Computed:         true,
+						ValidateFunc:     validateResourceQuantity,
+						DiffSuppressFunc: suppressEquivalentResourceQuantity,
+					},
 				},
 			},
 		},
@@ -168,6 +188,50 @@ func seLinuxOptionsField() map[string]*schema.Schema {
 	}
 }
 
+// securityProfileField is a seccomp or AppArmor profile, localhostProfile
+// describes where the Localhost profile is found on the node
+func securityProfileField(localhostProfile string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"localhost_profile": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: localhostProfile + " Must be set with `type = \"Localhost\"` only.",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateAttributeValueIsIn([]string{"Localhost", "RuntimeDefault", "Unconfined"}),
+			Description:  "Kind of profile, `Localhost` for a profile on the node, `RuntimeDefault` for the container runtime's default profile or `Unconfined`.",
+		},
+	}
+}
+
+// seccompProfileSchema is the seccomp profile of a security context
+func seccompProfileSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The seccomp options to use. Requires Kubernetes 1.19+.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: securityProfileField("Path of the profile on the node, relative to the kubelet's seccomp profile directory."),
+		},
+	}
+}
+
+// appArmorProfileSchema is the AppArmor profile of a security context
+func appArmorProfileSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The AppArmor options to use. Requires Kubernetes 1.30+.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: securityProfileField("Name of a profile loaded on the node."),
+		},
+	}
+}
+
 func volumeMountFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"mount_path": {
@@ -249,6 +313,11 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 												Optional:    true,
 												Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
 											},
+											"optional": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Description: "Specify whether the ConfigMap or its key must be defined.",
+											},
 										},
 									},
 								},
@@ -277,12 +346,19 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 									Type:        schema.TypeList,
 									Optional:    true,
 									MaxItems:    1,
-									Description: "Selects a field of the pod: supports metadata.name, metadata.namespace, metadata.labels, metadata.annotations, spec.nodeName, spec.serviceAccountName, status.podIP..",
+									Description: "Selects a resource of the container: only resources limits and requests (limits.cpu, limits.memory, requests.cpu and requests.memory) are currently supported.",
 									Elem: &schema.Resource{
 										Schema: map[string]*schema.Schema{
 											"container_name": {
-												Type:     schema.TypeString,
-												Optional: true,
+												Type:        schema.TypeString,
+												Optional:    true,
+												Description: "Name of the container whose resource to select. Defaults to the container of the variable.",
+											},
+											"divisor": {
+												Type:         schema.TypeString,
+												Optional:     true,
+												ValidateFunc: validateResourceQuantity,
+												Description:  "Unit the resource is exposed in, e.g. `1m` for millicores or `1Mi` for mebibytes. Defaults to `1`.",
 											},
 											"resource": {
 												Type:        schema.TypeString,
@@ -309,6 +385,11 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 												Optional:    true,
 												Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
 											},
+											"optional": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Description: "Specify whether the Secret or its key must be defined.",
+											},
 										},
 									},
 								},
@@ -318,6 +399,61 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 				},
 			},
 		},
+		"env_from": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "List of sources to populate environment variables in the container with. All keys of a source become variables, invalid keys are skipped and reported as an event. Variables defined by `env` take precedence, and of several sources the last one does. Cannot be updated.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"config_map_ref": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "The ConfigMap to select from.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+								},
+								"optional": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Description: "Specify whether the ConfigMap must be defined.",
+								},
+							},
+						},
+					},
+					"prefix": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "An optional identifier to prepend to each key in the ConfigMap or Secret. Must be a C_IDENTIFIER.",
+					},
+					"secret_ref": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "The Secret to select from.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:        schema.TypeString,
+									Required:    true,
+									Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+								},
+								"optional": {
+									Type:        schema.TypeBool,
+									Optional:    true,
+									Description: "Specify whether the Secret must be defined.",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"image": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -341,16 +477,18 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 						Type:        schema.TypeList,
 						Description: `post_start is called immediately after a container is created. If the handler fails, the container is terminated and restarted according to its restart policy. Other management of the container blocks until the hook completes. More info: http://kubernetes.io/docs/user-guide/container-environment#hook-details`,
 						Optional:    true,
+						MaxItems:    1,
 						Elem: &schema.Resource{
-							Schema: handlerFields(),
+							Schema: lifecycleHandlerFields(),
 						},
 					},
 					"pre_stop": {
 						Type:        schema.TypeList,
 						Description: `pre_stop is called immediately before a container is terminated. The container is terminated after the handler completes. The reason for termination is passed to the handler. Regardless of the outcome of the handler, the container is eventually terminated. Other management of the container blocks until the hook completes. More info: http://kubernetes.io/docs/user-guide/container-environment#hook-details`,
 						Optional:    true,
+						MaxItems:    1,
 						Elem: &schema.Resource{
-							Schema: handlerFields(),
+							Schema: lifecycleHandlerFields(),
 						},
 					},
 				},
@@ -433,6 +571,14 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 			Description: "Security options the pod should run with. More info: http://releases.k8s.io/HEAD/docs/design/security_context.md",
 			Elem:        securityContextSchema(),
 		},
+		"startup_probe": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			ForceNew:    true,
+			Description: "Probe which must succeed before liveness and readiness probes start, for containers which take long to start. Container will be restarted if the probe fails. Cannot be updated. Requires Kubernetes 1.16+. More info: https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle#container-probes",
+			Elem:        probeSchema(),
+		},
 		"stdin": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -487,8 +633,67 @@ func containerFields(isUpdatable bool) map[string]*schema.Schema {
 	return s
 }
 
+// initContainerFields are the container fields with the restart policy
+// which turns init containers into sidecars. Lifecycle hooks and probes
+// only make sense for those, which expandPodSpec checks.
+func initContainerFields(isUpdatable bool) map[string]*schema.Schema {
+	s := containerFields(isUpdatable)
+	s["restart_policy"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		ValidateFunc: validateAttributeValueIsIn([]string{"Always"}),
+		Description:  "Set to `Always` to run the init container as a sidecar, which is started before the containers and keeps running alongside them. Only sidecars may have `lifecycle` hooks and probes. Cannot be updated. Requires Kubernetes 1.28+. More info: https://kubernetes.io/docs/concepts/workloads/pods/sidecar-containers/",
+	}
+	return s
+}
+
+// lifecycleHandlerFields are the handler fields with the sleep action,
+// which only lifecycle hooks support
+func lifecycleHandlerFields() map[string]*schema.Schema {
+	h := handlerFields()
+	h["sleep"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Sleep pauses the container for the given duration, without depending on a `sleep` binary in the image. Requires Kubernetes 1.29+.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"seconds": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validateDuration(time.Second, 0),
+					Description:  "Number of seconds to sleep.",
+				},
+			},
+		},
+	}
+	return h
+}
+
 func probeSchema() *schema.Resource {
 	h := handlerFields()
+	h["grpc"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "GRPC specifies an action involving a gRPC port, checked with the standard gRPC health checking protocol. Requires Kubernetes 1.24+.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"port": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validatePortNum,
+					Description:  "Number of the port to access on the container. Number must be in the range 1 to 65535.",
+				},
+				"service": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the service to place in the gRPC HealthCheckRequest. If this is not specified, the default behavior is defined by gRPC.",
+				},
+			},
+		},
+	}
 	h["failure_threshold"] = &schema.Schema{
 		Type:         schema.TypeInt,
 		Optional:     true,
@@ -497,15 +702,16 @@ func probeSchema() *schema.Resource {
 		ValidateFunc: validatePositiveInteger,
 	}
 	h["initial_delay_seconds"] = &schema.Schema{
-		Type:        schema.TypeInt,
-		Optional:    true,
-		Description: "Number of seconds after the container has started before liveness probes are initiated. More info: http://kubernetes.io/docs/user-guide/pod-states#container-probes",
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Description:  "Number of seconds after the container has started before liveness probes are initiated. More info: http://kubernetes.io/docs/user-guide/pod-states#container-probes",
+		ValidateFunc: validateDuration(0, 0),
 	}
 	h["period_seconds"] = &schema.Schema{
 		Type:         schema.TypeInt,
 		Optional:     true,
 		Default:      10,
-		ValidateFunc: validatePositiveInteger,
+		ValidateFunc: validateDuration(time.Second, 0),
 		Description:  "How often (in seconds) to perform the probe",
 	}
 	h["success_threshold"] = &schema.Schema{
@@ -520,7 +726,7 @@ func probeSchema() *schema.Resource {
 		Type:         schema.TypeInt,
 		Optional:     true,
 		Default:      1,
-		ValidateFunc: validatePositiveInteger,
+		ValidateFunc: validateDuration(time.Second, 0),
 		Description:  "Number of seconds after which the probe times out. More info: http://kubernetes.io/docs/user-guide/pod-states#container-probes",
 	}
 	return &schema.Resource{
@@ -531,6 +737,12 @@ func probeSchema() *schema.Resource {
 
 func securityContextSchema() *schema.Resource {
 	m := map[string]*schema.Schema{
+		"allow_privilege_escalation": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether a process can gain more privileges than its parent process, e.g. through setuid binaries. Always true for `privileged` containers. Requires Kubernetes 1.8+.",
+		},
+		"app_armor_profile": appArmorProfileSchema(),
 		"privileged": {
 			Type:        schema.TypeBool,
 			Optional:    true,
@@ -543,6 +755,11 @@ func securityContextSchema() *schema.Resource {
 			Default:     false,
 			Description: "Whether this container has a read-only root filesystem.",
 		},
+		"run_as_group": {
+			Type:        schema.TypeInt,
+			Description: "The GID to run the entrypoint of the container process. Defaults to the group specified in image metadata if unspecified. Requires Kubernetes 1.14+.",
+			Optional:    true,
+		},
 		"run_as_non_root": {
 			Type:        schema.TypeBool,
 			Description: "Indicates that the container must run as a non-root user. If true, the Kubelet will validate the image at runtime to ensure that it does not run as UID 0 (root) and fail to start the container if it does.",
@@ -553,9 +770,10 @@ func securityContextSchema() *schema.Resource {
 			Description: "The UID to run the entrypoint of the container process. Defaults to user specified in image metadata if unspecified",
 			Optional:    true,
 		},
+		"seccomp_profile": seccompProfileSchema(),
 		"se_linux_options": {
 			Type:        schema.TypeList,
-			Description: "ImagePullSecrets is an optional list of references to secrets in the same namespace to use for pulling any of the images used by this PodSpec. If specified, these secrets will be passed to individual puller implementations for them to use. For example, in the case of docker, only DockerConfig type secrets are honored. More info: http://kubernetes.io/docs/user-guide/images#specifying-imagepullsecrets-on-a-pod",
+			Description: "The SELinux context to be applied to the container. If unspecified, the container runtime will allocate a random SELinux context for each container. May also be set in PodSecurityContext. If set in both SecurityContext and PodSecurityContext, the value specified in SecurityContext takes precedence.",
 			Optional:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -1,15 +1,27 @@
 package kubernetes
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
 func jobSpecFields() map[string]*schema.Schema {
+	podSpec := podSpecFields(true)
+	// The API rejects job pods which are restarted Always
+	podSpec["restart_policy"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "Never",
+		ValidateFunc: validateAttributeValueIsIn([]string{"OnFailure", "Never"}),
+		Description:  "Restart policy for all containers within the pod. One of OnFailure, Never. More info: https://kubernetes.io/docs/concepts/workloads/controllers/jobs-run-to-completion/#handling-pod-and-container-failures",
+	}
+
 	s := map[string]*schema.Schema{
 		"active_deadline_seconds": {
 			Type:         schema.TypeInt,
 			Optional:     true,
-			ValidateFunc: validatePositiveInteger,
+			ValidateFunc: validateDuration(time.Second, 0),
 			Description:  "Optional duration in seconds the pod may be active on the node relative to StartTime before the system will actively try to mark it failed and kill associated containers. Value must be a positive integer.",
 		},
 		"completions": {
@@ -83,10 +95,29 @@ func jobSpecFields() map[string]*schema.Schema {
 			Required:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: podSpecFields(true),
+				Schema: podSpec,
 			},
 		},
 	}
 
+	// The API refuses to update these, so the job is replaced when they change
+	for _, k := range []string{"completions", "manual_selector", "selector", "template"} {
+		forceNew(s[k])
+	}
+
 	return s
 }
+
+// forceNew marks the field and all fields nested in it ForceNew,
+// as ForceNew on a list only applies to its number of items
+func forceNew(s *schema.Schema) {
+	if s.Computed && !s.Optional {
+		return
+	}
+	s.ForceNew = true
+	if r, ok := s.Elem.(*schema.Resource); ok {
+		for _, f := range r.Schema {
+			forceNew(f)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func meshInjectionSchema(isUpdatable bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Sets the well-known sidecar injection annotation of a service mesh on the pod template.",
+		Optional:    true,
+		ForceNew:    !isUpdatable,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"provider": {
+					Type:         schema.TypeString,
+					Description:  "Service mesh whose injection annotation is managed. One of `istio` or `linkerd`.",
+					Required:     true,
+					ForceNew:     !isUpdatable,
+					ValidateFunc: validateAttributeValueIsIn(meshInjectionProviders()),
+				},
+				"enabled": {
+					Type:        schema.TypeBool,
+					Description: "Whether the sidecar should be injected. Setting this to false explicitly opts out of injection enabled at the namespace level.",
+					Optional:    true,
+					ForceNew:    !isUpdatable,
+					Default:     true,
+				},
+			},
+		},
+	}
+}
@@ -31,7 +31,7 @@ func metadataFields(objectName string) map[string]*schema.Schema {
 			Optional:     true,
 			ForceNew:     true,
 			Computed:     true,
-			ValidateFunc: validateName,
+			ValidateFunc: validateMetadataName(objectName),
 		},
 		"resource_version": {
 			Type:        schema.TypeString,
@@ -60,7 +60,7 @@ func metadataSchema(objectName string, generatableName bool) *schema.Schema {
 			Description:   "Prefix, used by the server, to generate a unique name ONLY IF the `name` field has not been provided. This value will also be combined with a unique suffix. Read more: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#idempotency",
 			Optional:      true,
 			ForceNew:      true,
-			ValidateFunc:  validateGenerateName,
+			ValidateFunc:  validateMetadataGenerateName(objectName),
 			ConflictsWith: []string{"metadata.name"},
 		}
 		fields["name"].ConflictsWith = []string{"metadata.generate_name"}
@@ -92,7 +92,7 @@ func namespacedMetadataSchema(objectName string, generatableName bool) *schema.S
 			Description:   "Prefix, used by the server, to generate a unique name ONLY IF the `name` field has not been provided. This value will also be combined with a unique suffix. Read more: https://github.com/kubernetes/community/blob/master/contributors/devel/api-conventions.md#idempotency",
 			Optional:      true,
 			ForceNew:      true,
-			ValidateFunc:  validateGenerateName,
+			ValidateFunc:  validateMetadataGenerateName(objectName),
 			ConflictsWith: []string{"metadata.name"},
 		}
 		fields["name"].ConflictsWith = []string{"metadata.generate_name"}
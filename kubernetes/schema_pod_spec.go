@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 )
 
@@ -9,9 +11,16 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 		"active_deadline_seconds": {
 			Type:         schema.TypeInt,
 			Optional:     true,
-			ValidateFunc: validatePositiveInteger,
+			ValidateFunc: validateDuration(time.Second, 0),
 			Description:  "Optional duration in seconds the pod may be active on the node relative to StartTime before the system will actively try to mark it failed and kill associated containers. Value must be a positive integer.",
 		},
+		"affinity": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Scheduling constraints of the pod relative to nodes and other pods. More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/#affinity-and-anti-affinity",
+			Elem:        affinitySchema(),
+		},
 		"container": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -20,6 +29,14 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 				Schema: containerFields(isUpdatable),
 			},
 		},
+		"init_container": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "List of init containers belonging to the pod. Init containers are run in order before the containers are started, each must complete successfully before the next one is run. Cannot be updated. More info: https://kubernetes.io/docs/concepts/workloads/pods/init-containers/",
+			Elem: &schema.Resource{
+				Schema: initContainerFields(isUpdatable),
+			},
+		},
 		"dns_policy": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -74,15 +91,24 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			Description: "NodeName is a request to schedule this pod onto a specific node. If it is non-empty, the scheduler simply schedules this pod onto that node, assuming that it fits resource requirements.",
 		},
 		"node_selector": {
-			Type:        schema.TypeMap,
-			Optional:    true,
-			Description: "NodeSelector is a selector which must be true for the pod to fit on a node. Selector which must match a node's labels for the pod to be scheduled on that node. More info: http://kubernetes.io/docs/user-guide/node-selection.",
+			Type:             schema.TypeMap,
+			Optional:         true,
+			Description:      "NodeSelector is a selector which must be true for the pod to fit on a node. Selector which must match a node's labels for the pod to be scheduled on that node. More info: http://kubernetes.io/docs/user-guide/node-selection.",
+			DiffSuppressFunc: suppressNodeSelectorSetByOs,
+		},
+		"os": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			ValidateFunc:     validateAttributeValueIsIn([]string{"linux", "windows"}),
+			Description:      "Operating system of the nodes the pod must be scheduled on, `linux` or `windows`. Sets the `kubernetes.io/os` node selector. Fields not supported by Windows are rejected when `windows`.",
+			DiffSuppressFunc: suppressOsSetByNodeSelector,
 		},
 		"restart_policy": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "Always",
-			Description: "Restart policy for all containers within the pod. One of Always, OnFailure, Never. More info: http://kubernetes.io/docs/user-guide/pod-states#restartpolicy.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Always",
+			ValidateFunc: validateRestartPolicy,
+			Description:  "Restart policy for all containers within the pod. One of Always, OnFailure, Never. More info: http://kubernetes.io/docs/user-guide/pod-states#restartpolicy.",
 		},
 		"security_context": {
 			Type:        schema.TypeList,
@@ -91,11 +117,23 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			Description: "SecurityContext holds pod-level security attributes and common container settings. Optional: Defaults to empty",
 			Elem: &schema.Resource{
 				Schema: map[string]*schema.Schema{
+					"app_armor_profile": appArmorProfileSchema(),
 					"fs_group": {
 						Type:        schema.TypeInt,
 						Description: "A special supplemental group that applies to all containers in a pod. Some volume types allow the Kubelet to change the ownership of that volume to be owned by the pod: 1. The owning GID will be the FSGroup 2. The setgid bit is set (new files created in the volume will be owned by FSGroup) 3. The permission bits are OR'd with rw-rw---- If unset, the Kubelet will not modify the ownership and permissions of any volume.",
 						Optional:    true,
 					},
+					"fs_group_change_policy": {
+						Type:         schema.TypeString,
+						Description:  "When the ownership and permissions of volumes are changed to `fs_group`, `OnRootMismatch` to skip volumes whose root already matches or `Always`. Requires Kubernetes 1.20+.",
+						Optional:     true,
+						ValidateFunc: validateAttributeValueIsIn([]string{"Always", "OnRootMismatch"}),
+					},
+					"run_as_group": {
+						Type:        schema.TypeInt,
+						Description: "The GID to run the entrypoint of the container process. Defaults to the group specified in image metadata if unspecified. Requires Kubernetes 1.14+.",
+						Optional:    true,
+					},
 					"run_as_non_root": {
 						Type:        schema.TypeBool,
 						Description: "Indicates that the container must run as a non-root user. If true, the Kubelet will validate the image at runtime to ensure that it does not run as UID 0 (root) and fail to start the container if it does.",
@@ -106,6 +144,7 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 						Description: "The UID to run the entrypoint of the container process. Defaults to user specified in image metadata if unspecified",
 						Optional:    true,
 					},
+					"seccomp_profile": seccompProfileSchema(),
 					"supplemental_groups": {
 						Type:        schema.TypeSet,
 						Description: "A list of groups applied to the first process run in each container, in addition to the container's primary GID. If unspecified, no groups will be added to any container.",
@@ -141,10 +180,87 @@ func podSpecFields(isUpdatable bool) map[string]*schema.Schema {
 			Type:         schema.TypeInt,
 			Optional:     true,
 			Default:      30,
-			ValidateFunc: validateTerminationGracePeriodSeconds,
+			ValidateFunc: validateDuration(0, 0),
 			Description:  "Optional duration in seconds the pod needs to terminate gracefully. May be decreased in delete request. Value must be non-negative integer. The value zero indicates delete immediately. If this value is nil, the default grace period will be used instead. The grace period is the duration in seconds after the processes running in the pod are sent a termination signal and the time when the processes are forcibly halted with a kill signal. Set this value longer than the expected cleanup time for your process.",
 		},
-
+		"toleration": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "List of tolerations of the pod, allowing it to be scheduled onto nodes with matching taints. More info: https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"effect": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validateAttributeValueIsIn([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}),
+						Description:  "Taint effect to match, one of `NoSchedule`, `PreferNoSchedule` and `NoExecute`. All effects are matched when empty.",
+					},
+					"key": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Taint key the toleration applies to. All taint keys are matched when empty, `operator` must be `Exists` then.",
+					},
+					"operator": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validateAttributeValueIsIn([]string{"Equal", "Exists"}),
+						Description:  "Key's relationship to the value, `Equal` or `Exists`. `Exists` matches all values of the key. Defaults to `Equal`.",
+					},
+					"toleration_seconds": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validateDuration(time.Second, 0),
+						Description:  "Only applies to the `NoExecute` effect. Period of time the pod keeps running on the node after the taint is added, it's tolerated forever when not set.",
+					},
+					"value": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Taint value the toleration matches with the `Equal` operator.",
+					},
+				},
+			},
+		},
+		"topology_spread_constraint": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "How the pods are spread across topology domains such as zones or nodes. Requires Kubernetes 1.19+. More info: https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"label_selector": {
+						Type:        schema.TypeList,
+						Description: "A label query over the pods counted in each topology domain.",
+						Optional:    true,
+						MaxItems:    1,
+						Elem:        podLabelSelectorSchema(),
+					},
+					"max_skew": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      1,
+						ValidateFunc: validatePositiveInteger,
+						Description:  "Maximum difference in the number of matching pods between any two topology domains.",
+					},
+					"min_domains": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						ValidateFunc: validatePositiveInteger,
+						Description:  "Minimum number of eligible domains, fewer domains count as having zero matching pods. Only applies with `when_unsatisfiable = \"DoNotSchedule\"`. Requires Kubernetes 1.25+.",
+					},
+					"topology_key": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "Node label key whose values define the topology domains, e.g. `topology.kubernetes.io/zone`.",
+					},
+					"when_unsatisfiable": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "DoNotSchedule",
+						ValidateFunc: validateAttributeValueIsIn([]string{"DoNotSchedule", "ScheduleAnyway"}),
+						Description:  "What to do with a pod that doesn't satisfy the constraint, `DoNotSchedule` or `ScheduleAnyway`. Defaults to `DoNotSchedule`.",
+					},
+				},
+			},
+		},
 		"volume": {
 			Type:        schema.TypeList,
 			Optional:    true,
@@ -254,9 +370,11 @@ func volumeSchema() *schema.Resource {
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"default_mode": {
-					Type:        schema.TypeInt,
-					Description: "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
-					Optional:    true,
+					Type:         schema.TypeInt,
+					Description:  "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
+					Optional:     true,
+					Default:      0644,
+					ValidateFunc: validateModeBits,
 				},
 				"items": {
 					Type:        schema.TypeList,
@@ -304,12 +422,20 @@ func volumeSchema() *schema.Resource {
 								Elem: &schema.Resource{
 									Schema: map[string]*schema.Schema{
 										"container_name": {
-											Type:     schema.TypeString,
-											Required: true,
+											Type:        schema.TypeString,
+											Required:    true,
+											Description: "Name of the container whose resource to select.",
+										},
+										"divisor": {
+											Type:         schema.TypeString,
+											Optional:     true,
+											ValidateFunc: validateResourceQuantity,
+											Description:  "Unit the resource is exposed in, e.g. `1m` for millicores or `1Mi` for mebibytes. Defaults to `1`.",
 										},
 										"quantity": {
-											Type:     schema.TypeString,
-											Optional: true,
+											Type:       schema.TypeString,
+											Optional:   true,
+											Deprecated: "It never had an effect, use divisor instead.",
 										},
 										"resource": {
 											Type:        schema.TypeString,
@@ -418,6 +544,177 @@ func volumeSchema() *schema.Resource {
 			},
 		},
 	}
+	v["projected"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Projected represents secrets, config maps, downward API items and service account tokens projected into a single directory. More info: https://kubernetes.io/docs/concepts/storage/projected-volumes/",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"default_mode": {
+					Type:         schema.TypeInt,
+					Description:  "Optional: mode bits to use on created files by default. Must be a value between 0 and 0777. Defaults to 0644. Directories within the path are not affected by this setting. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
+					Optional:     true,
+					Default:      0644,
+					ValidateFunc: validateModeBits,
+				},
+				"sources": {
+					Type:        schema.TypeList,
+					Description: "List of sources to project, each of them holding one of `config_map`, `downward_api`, `secret` and `service_account_token`.",
+					Required:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"config_map": {
+								Type:        schema.TypeList,
+								Description: "ConfigMap to project.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem:        keyToPathProjectionSchema("ConfigMap"),
+							},
+							"downward_api": {
+								Type:        schema.TypeList,
+								Description: "Downward API items to project.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										// Same as the downward API volume's items
+										"items": v["downward_api"].Elem.(*schema.Resource).Schema["items"],
+									},
+								},
+							},
+							"secret": {
+								Type:        schema.TypeList,
+								Description: "Secret to project.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem:        keyToPathProjectionSchema("Secret"),
+							},
+							"service_account_token": {
+								Type:        schema.TypeList,
+								Description: "Token of the pod's service account to project. Requires Kubernetes 1.12+.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"audience": {
+											Type:        schema.TypeString,
+											Description: "Intended audience of the token, a recipient of the token must identify itself with it. Defaults to the identifier of the API server.",
+											Optional:    true,
+										},
+										"expiration_seconds": {
+											Type:         schema.TypeInt,
+											Description:  "Requested duration of validity of the token, the kubelet rotates it when 80% of that passed or it is older than 24 hours. Must be at least 10 minutes. Defaults to 1 hour.",
+											Optional:     true,
+											Default:      3600,
+											ValidateFunc: validateDuration(10*time.Minute, 0),
+										},
+										"path": {
+											Type:        schema.TypeString,
+											Description: "Path relative to the mount point of the file to project the token into.",
+											Required:    true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	v["csi"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "A volume provided by a CSI driver which supports inline (ephemeral) volumes, such as the Secrets Store CSI driver. Requires Kubernetes 1.16+.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"driver": {
+					Type:        schema.TypeString,
+					Description: "Name of the CSI driver handling the volume, as registered in the cluster.",
+					Required:    true,
+				},
+				"fs_type": {
+					Type:        schema.TypeString,
+					Description: "Filesystem type to mount, e.g. `ext4`. Passed to the driver, which picks its own default when empty.",
+					Optional:    true,
+				},
+				"node_publish_secret_ref": {
+					Type:        schema.TypeList,
+					Description: "Secret holding sensitive information passed to the driver when mounting the volume.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:        schema.TypeString,
+								Description: "Name of the secret in the pod's namespace.",
+								Required:    true,
+							},
+						},
+					},
+				},
+				"read_only": {
+					Type:        schema.TypeBool,
+					Description: "Whether the volume is mounted read-only.",
+					Optional:    true,
+				},
+				"volume_attributes": {
+					Type:        schema.TypeMap,
+					Description: "Driver-specific properties of the volume.",
+					Optional:    true,
+				},
+			},
+		},
+	}
+	v["ephemeral"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "A volume backed by a persistent volume claim which is created with the pod and deleted with it. Requires Kubernetes 1.23+.",
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"volume_claim_template": {
+					Type:        schema.TypeList,
+					Description: "Template of the persistent volume claim created for the pod, named `<pod name>-<volume name>`.",
+					Required:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"metadata": {
+								Type:        schema.TypeList,
+								Description: "Labels and annotations copied to the claim.",
+								Optional:    true,
+								MaxItems:    1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"annotations": {
+											Type:        schema.TypeMap,
+											Description: "Annotations of the claim.",
+											Optional:    true,
+										},
+										"labels": {
+											Type:        schema.TypeMap,
+											Description: "Labels of the claim.",
+											Optional:    true,
+										},
+									},
+								},
+							},
+							"spec": {
+								Type:        schema.TypeList,
+								Description: "Spec of the claim, like the `spec` of `kubernetes_persistent_volume_claim`.",
+								Required:    true,
+								MaxItems:    1,
+								Elem:        persistentVolumeClaimSpecSchema(false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
 	v["name"] = &schema.Schema{
 		Type:        schema.TypeString,
 		Description: "Volume's name. Must be a DNS_LABEL and unique within the pod. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
@@ -427,3 +724,47 @@ func volumeSchema() *schema.Resource {
 		Schema: v,
 	}
 }
+
+// keyToPathProjectionSchema is the schema of a projected secret or config map
+func keyToPathProjectionSchema(kind string) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"items": {
+				Type:        schema.TypeList,
+				Description: "If unspecified, each key-value pair in the Data field of the referenced " + kind + " will be projected into the volume as a file whose name is the key and content is the value. If specified, the listed keys will be projected into the specified paths, and unlisted keys will not be present. If a key is specified which is not present in the " + kind + ", the volume setup will error unless it is marked optional. Paths must be relative and may not contain the '..' path or start with '..'.",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The key to project.",
+						},
+						"mode": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateModeBits,
+							Description:  "Optional: mode bits to use on this file, must be a value between 0 and 0777. If not specified, the volume defaultMode will be used. This might be in conflict with other options that affect the file mode, like fsGroup, and the result can be other mode bits set.",
+						},
+						"path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAttributeValueDoesNotContain(".."),
+							Description:  "The relative path of the file to map the key to. May not be an absolute path. May not contain the path element '..'. May not start with the string '..'.",
+						},
+					},
+				},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names",
+				Required:    true,
+			},
+			"optional": {
+				Type:        schema.TypeBool,
+				Description: "Optional: Specify whether the " + kind + " or its keys must be defined.",
+				Optional:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func policyRuleSchema() *schema.Schema {
+	stringList := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeList,
+			Description: description,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		}
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Rules granting permissions.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"verbs":             stringList("Verbs allowed by the rule, `*` meaning all."),
+				"api_groups":        stringList("API groups of the resources, an empty string being the core group."),
+				"resources":         stringList("Resources the rule applies to, `*` meaning all."),
+				"resource_names":    stringList("Names of the resources the rule is restricted to. All names when empty."),
+				"non_resource_urls": stringList("Non-resource URLs the rule applies to, e.g. `/healthz`. Only in cluster roles."),
+			},
+		},
+	}
+}
+
+func roleRefSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The role being granted.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"api_group": {
+					Type:        schema.TypeString,
+					Description: "API group of the role.",
+					Computed:    true,
+				},
+				"kind": {
+					Type:        schema.TypeString,
+					Description: "Kind of the role, `Role` or `ClusterRole`.",
+					Computed:    true,
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "Name of the role.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+func subjectSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Users, groups or service accounts the role is granted to.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"kind": {
+					Type:        schema.TypeString,
+					Description: "Kind of the subject, `User`, `Group` or `ServiceAccount`.",
+					Computed:    true,
+				},
+				"api_group": {
+					Type:        schema.TypeString,
+					Description: "API group of the subject.",
+					Computed:    true,
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "Name of the subject.",
+					Computed:    true,
+				},
+				"namespace": {
+					Type:        schema.TypeString,
+					Description: "Namespace of the service account. Empty for users and groups.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
@@ -1,12 +1,19 @@
 package kubernetes
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
-	"k8s.io/kubernetes/pkg/api/v1"
 	batchv1 "k8s.io/kubernetes/pkg/apis/batch/v1"
 )
 
-func flattenJobSpec(in batchv1.JobSpec) ([]interface{}, error) {
+// jobSpec is a job spec with a podTemplateSpec
+type jobSpec struct {
+	batchv1.JobSpec
+	Template podTemplateSpec `json:"template"`
+}
+
+func flattenJobSpec(in jobSpec) ([]interface{}, error) {
 	att := make(map[string]interface{})
 
 	if in.ActiveDeadlineSeconds != nil {
@@ -38,8 +45,42 @@ func flattenJobSpec(in batchv1.JobSpec) ([]interface{}, error) {
 	return []interface{}{att}, nil
 }
 
-func expandJobSpec(j []interface{}) (batchv1.JobSpec, error) {
-	obj := batchv1.JobSpec{}
+func flattenJobStatus(in batchv1.JobStatus) []interface{} {
+	att := map[string]interface{}{
+		"active":          int(in.Active),
+		"succeeded":       int(in.Succeeded),
+		"failed":          int(in.Failed),
+		"start_time":      "",
+		"completion_time": "",
+	}
+	if in.StartTime != nil {
+		att["start_time"] = in.StartTime.UTC().Format(time.RFC3339)
+	}
+	if in.CompletionTime != nil {
+		att["completion_time"] = in.CompletionTime.UTC().Format(time.RFC3339)
+	}
+
+	conditions := make([]interface{}, len(in.Conditions))
+	for i, c := range in.Conditions {
+		condition := map[string]interface{}{
+			"type":                 string(c.Type),
+			"status":               string(c.Status),
+			"reason":               c.Reason,
+			"message":              c.Message,
+			"last_transition_time": "",
+		}
+		if !c.LastTransitionTime.IsZero() {
+			condition["last_transition_time"] = c.LastTransitionTime.UTC().Format(time.RFC3339)
+		}
+		conditions[i] = condition
+	}
+	att["condition"] = conditions
+
+	return []interface{}{att}
+}
+
+func expandJobSpec(j []interface{}) (jobSpec, error) {
+	obj := jobSpec{}
 
 	if len(j) == 0 || j[0] == nil {
 		return obj, nil
@@ -72,7 +113,7 @@ func expandJobSpec(j []interface{}) (batchv1.JobSpec, error) {
 		return obj, err
 	}
 
-	obj.Template = v1.PodTemplateSpec{
+	obj.Template = podTemplateSpec{
 		Spec: podSpec,
 	}
 
@@ -91,5 +132,12 @@ func patchJobSpec(pathPrefix, prefix string, d *schema.ResourceData) (PatchOpera
 		})
 	}
 
+	if d.HasChange(prefix + "parallelism") {
+		ops = append(ops, &ReplaceOperation{
+			Path:  pathPrefix + "/parallelism",
+			Value: d.Get(prefix + "parallelism").(int),
+		})
+	}
+
 	return ops, nil
 }
@@ -0,0 +1,76 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	batchv1 "k8s.io/kubernetes/pkg/apis/batch/v1"
+)
+
+func TestFlattenJobStatus(t *testing.T) {
+	start := metav1.NewTime(time.Date(2017, 9, 1, 10, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(time.Date(2017, 9, 1, 10, 5, 0, 0, time.UTC))
+
+	cases := []struct {
+		Input          batchv1.JobStatus
+		ExpectedOutput []interface{}
+	}{
+		{
+			batchv1.JobStatus{Active: 2},
+			[]interface{}{
+				map[string]interface{}{
+					"active":          2,
+					"succeeded":       0,
+					"failed":          0,
+					"start_time":      "",
+					"completion_time": "",
+					"condition":       []interface{}{},
+				},
+			},
+		},
+		{
+			batchv1.JobStatus{
+				StartTime:      &start,
+				CompletionTime: &end,
+				Succeeded:      1,
+				Failed:         1,
+				Conditions: []batchv1.JobCondition{
+					{
+						Type:               batchv1.JobComplete,
+						Status:             api.ConditionTrue,
+						LastTransitionTime: end,
+					},
+				},
+			},
+			[]interface{}{
+				map[string]interface{}{
+					"active":          0,
+					"succeeded":       1,
+					"failed":          1,
+					"start_time":      "2017-09-01T10:00:00Z",
+					"completion_time": "2017-09-01T10:05:00Z",
+					"condition": []interface{}{
+						map[string]interface{}{
+							"type":                 "Complete",
+							"status":               "True",
+							"reason":               "",
+							"message":              "",
+							"last_transition_time": "2017-09-01T10:05:00Z",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenJobStatus(tc.Input)
+		if !reflect.DeepEqual(output, tc.ExpectedOutput) {
+			t.Fatalf("Unexpected output from flattener.\nExpected: %#v\nGiven:    %#v",
+				tc.ExpectedOutput, output)
+		}
+	}
+}
@@ -766,7 +766,7 @@ func patchPersistentVolumeSpec(pathPrefix, prefix string, d *schema.ResourceData
 			Value: expandPersistentVolumeAccessModes(v.List()),
 		})
 	}
-	if d.HasChange(prefix + "access_modes") {
+	if d.HasChange(prefix + "persistent_volume_reclaim_policy") {
 		v := d.Get(prefix + "persistent_volume_reclaim_policy").(string)
 		ops = append(ops, &ReplaceOperation{
 			Path:  pathPrefix + "/persistentVolumeReclaimPolicy",
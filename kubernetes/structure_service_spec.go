@@ -6,6 +6,28 @@ import (
 	"k8s.io/kubernetes/pkg/api/v1"
 )
 
+// serviceSpec is a service spec including fields which the vendored API types predate
+type serviceSpec struct {
+	v1.ServiceSpec
+	ExternalTrafficPolicy         string                 `json:"externalTrafficPolicy,omitempty"`
+	HealthCheckNodePort           int32                  `json:"healthCheckNodePort,omitempty"`
+	SessionAffinityConfig         *sessionAffinityConfig `json:"sessionAffinityConfig,omitempty"`
+	ClusterIPs                    []string               `json:"clusterIPs,omitempty"`
+	IPFamilies                    []string               `json:"ipFamilies,omitempty"`
+	IPFamilyPolicy                string                 `json:"ipFamilyPolicy,omitempty"`
+	InternalTrafficPolicy         string                 `json:"internalTrafficPolicy,omitempty"`
+	LoadBalancerClass             string                 `json:"loadBalancerClass,omitempty"`
+	AllocateLoadBalancerNodePorts *bool                  `json:"allocateLoadBalancerNodePorts,omitempty"`
+}
+
+type sessionAffinityConfig struct {
+	ClientIP *clientIPConfig `json:"clientIP,omitempty"`
+}
+
+type clientIPConfig struct {
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
 // Flatteners
 
 func flattenIntOrString(in intstr.IntOrString) int {
@@ -27,7 +49,7 @@ func flattenServicePort(in []v1.ServicePort) []interface{} {
 	return att
 }
 
-func flattenServiceSpec(in v1.ServiceSpec) []interface{} {
+func flattenServiceSpec(in serviceSpec) []interface{} {
 	att := make(map[string]interface{})
 	if len(in.Ports) > 0 {
 		att["port"] = flattenServicePort(in.Ports)
@@ -56,6 +78,39 @@ func flattenServiceSpec(in v1.ServiceSpec) []interface{} {
 	if in.ExternalName != "" {
 		att["external_name"] = in.ExternalName
 	}
+	if in.ExternalTrafficPolicy != "" {
+		att["external_traffic_policy"] = in.ExternalTrafficPolicy
+	}
+	att["health_check_node_port"] = int(in.HealthCheckNodePort)
+	if in.SessionAffinityConfig != nil {
+		att["session_affinity_config"] = flattenSessionAffinityConfig(*in.SessionAffinityConfig)
+	}
+	att["cluster_ips"] = in.ClusterIPs
+	if len(in.IPFamilies) > 0 {
+		att["ip_families"] = in.IPFamilies
+	}
+	if in.IPFamilyPolicy != "" {
+		att["ip_family_policy"] = in.IPFamilyPolicy
+	}
+	if in.InternalTrafficPolicy != "" {
+		att["internal_traffic_policy"] = in.InternalTrafficPolicy
+	}
+	if in.LoadBalancerClass != "" {
+		att["load_balancer_class"] = in.LoadBalancerClass
+	}
+	att["allocate_load_balancer_node_ports"] = in.AllocateLoadBalancerNodePorts == nil || *in.AllocateLoadBalancerNodePorts
+	return []interface{}{att}
+}
+
+func flattenSessionAffinityConfig(in sessionAffinityConfig) []interface{} {
+	att := make(map[string]interface{})
+	if in.ClientIP != nil {
+		ip := make(map[string]interface{})
+		if in.ClientIP.TimeoutSeconds != nil {
+			ip["timeout_seconds"] = int(*in.ClientIP.TimeoutSeconds)
+		}
+		att["client_ip"] = []interface{}{ip}
+	}
 	return []interface{}{att}
 }
 
@@ -102,12 +157,12 @@ func expandServicePort(l []interface{}) []v1.ServicePort {
 	return obj
 }
 
-func expandServiceSpec(l []interface{}) v1.ServiceSpec {
+func expandServiceSpec(l []interface{}) serviceSpec {
 	if len(l) == 0 || l[0] == nil {
-		return v1.ServiceSpec{}
+		return serviceSpec{}
 	}
 	in := l[0].(map[string]interface{})
-	obj := v1.ServiceSpec{}
+	obj := serviceSpec{}
 
 	if v, ok := in["port"].([]interface{}); ok && len(v) > 0 {
 		obj.Ports = expandServicePort(v)
@@ -136,6 +191,46 @@ func expandServiceSpec(l []interface{}) v1.ServiceSpec {
 	if v, ok := in["external_name"].(string); ok {
 		obj.ExternalName = v
 	}
+	if v, ok := in["external_traffic_policy"].(string); ok {
+		obj.ExternalTrafficPolicy = v
+	}
+	if v, ok := in["session_affinity_config"].([]interface{}); ok && len(v) > 0 {
+		obj.SessionAffinityConfig = expandSessionAffinityConfig(v)
+	}
+	if v, ok := in["ip_families"].([]interface{}); ok && len(v) > 0 {
+		obj.IPFamilies = sliceOfString(v)
+	}
+	if v, ok := in["ip_family_policy"].(string); ok {
+		obj.IPFamilyPolicy = v
+	}
+	if v, ok := in["internal_traffic_policy"].(string); ok {
+		obj.InternalTrafficPolicy = v
+	}
+	if v, ok := in["load_balancer_class"].(string); ok {
+		obj.LoadBalancerClass = v
+	}
+	if v, ok := in["allocate_load_balancer_node_ports"].(bool); ok && obj.Type == v1.ServiceTypeLoadBalancer {
+		obj.AllocateLoadBalancerNodePorts = ptrToBool(v)
+	}
+	return obj
+}
+
+func expandSessionAffinityConfig(l []interface{}) *sessionAffinityConfig {
+	obj := &sessionAffinityConfig{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["client_ip"].([]interface{}); ok && len(v) > 0 {
+		obj.ClientIP = &clientIPConfig{}
+		if v[0] == nil {
+			return obj
+		}
+		ip := v[0].(map[string]interface{})
+		if t, ok := ip["timeout_seconds"].(int); ok && t > 0 {
+			obj.ClientIP.TimeoutSeconds = ptrToInt32(int32(t))
+		}
+	}
 	return obj
 }
 
@@ -197,5 +292,50 @@ func patchServiceSpec(keyPrefix, pathPrefix string, d *schema.ResourceData) Patc
 			Value: d.Get(keyPrefix + "external_name").(string),
 		})
 	}
+	// Fields the vendored API types predate are omitted while empty, add them
+	if d.HasChange(keyPrefix + "session_affinity_config") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "sessionAffinityConfig",
+			Value: expandSessionAffinityConfig(d.Get(keyPrefix + "session_affinity_config").([]interface{})),
+		})
+	} else if d.HasChange(keyPrefix+"session_affinity") && d.Get(keyPrefix+"session_affinity").(string) == "None" {
+		// The config defaulted for ClientIP affinity must be cleared along with it
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "sessionAffinityConfig",
+			Value: nil,
+		})
+	}
+	if d.HasChange(keyPrefix + "ip_families") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "ipFamilies",
+			Value: sliceOfString(d.Get(keyPrefix + "ip_families").([]interface{})),
+		})
+	}
+	if d.HasChange(keyPrefix + "ip_family_policy") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "ipFamilyPolicy",
+			Value: d.Get(keyPrefix + "ip_family_policy").(string),
+		})
+	}
+	// Only valid for load balancers, so it's (re)sent whenever a service becomes one
+	if (d.HasChange(keyPrefix+"allocate_load_balancer_node_ports") || d.HasChange(keyPrefix+"type")) &&
+		d.Get(keyPrefix+"type").(string) == string(v1.ServiceTypeLoadBalancer) {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "allocateLoadBalancerNodePorts",
+			Value: d.Get(keyPrefix + "allocate_load_balancer_node_ports").(bool),
+		})
+	}
+	if d.HasChange(keyPrefix + "internal_traffic_policy") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "internalTrafficPolicy",
+			Value: d.Get(keyPrefix + "internal_traffic_policy").(string),
+		})
+	}
+	if d.HasChange(keyPrefix + "external_traffic_policy") {
+		ops = append(ops, &AddOperation{
+			Path:  pathPrefix + "externalTrafficPolicy",
+			Value: d.Get(keyPrefix + "external_traffic_policy").(string),
+		})
+	}
 	return ops
 }
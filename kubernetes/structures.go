@@ -163,6 +163,48 @@ func base64EncodeStringMap(m map[string]interface{}) map[string]interface{} {
 	return result
 }
 
+func base64DecodeStringMap(m map[string]interface{}) map[string][]byte {
+	result := make(map[string][]byte)
+	for k, v := range m {
+		// Values are validated by validateBase64EncodedMap
+		b, _ := base64.StdEncoding.DecodeString(v.(string))
+		result[k] = b
+	}
+	return result
+}
+
+func base64EncodeByteMap(m map[string][]byte) map[string]string {
+	result := make(map[string]string)
+	for k, v := range m {
+		result[k] = base64.StdEncoding.EncodeToString(v)
+	}
+	return result
+}
+
+// withoutKeys returns a copy of the map without the given keys
+func withoutKeys(m map[string]interface{}, keys []string) map[string]interface{} {
+	result := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	for _, k := range keys {
+		delete(result, k)
+	}
+	return result
+}
+
+// checkIgnoredKeys fails if keys managed elsewhere are set in any of the maps
+func checkIgnoredKeys(ignored []string, maps ...map[string]interface{}) error {
+	for _, k := range ignored {
+		for _, m := range maps {
+			if _, ok := m[k]; ok {
+				return fmt.Errorf("Key %q is listed in ignore_keys and can't be set", k)
+			}
+		}
+	}
+	return nil
+}
+
 func flattenResourceList(l api.ResourceList) map[string]string {
 	m := make(map[string]string)
 	for k, v := range l {
@@ -0,0 +1,224 @@
+package kubernetes
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+// Flatteners
+
+func flattenAffinity(in *v1.Affinity) []interface{} {
+	att := make(map[string]interface{})
+	if in.NodeAffinity != nil {
+		att["node_affinity"] = flattenNodeAffinity(in.NodeAffinity)
+	}
+	if in.PodAffinity != nil {
+		att["pod_affinity"] = flattenPodAffinityTerms(in.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			in.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	if in.PodAntiAffinity != nil {
+		att["pod_anti_affinity"] = flattenPodAffinityTerms(in.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			in.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	}
+	if len(att) > 0 {
+		return []interface{}{att}
+	}
+	return []interface{}{}
+}
+
+func flattenNodeAffinity(in *v1.NodeAffinity) []interface{} {
+	att := make(map[string]interface{})
+	if in.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		terms := make([]interface{}, len(in.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms))
+		for i, t := range in.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+			terms[i] = flattenNodeSelectorTerm(t)
+		}
+		att["required_during_scheduling_ignored_during_execution"] = []interface{}{
+			map[string]interface{}{
+				"node_selector_term": terms,
+			},
+		}
+	}
+	if len(in.PreferredDuringSchedulingIgnoredDuringExecution) > 0 {
+		preferred := make([]interface{}, len(in.PreferredDuringSchedulingIgnoredDuringExecution))
+		for i, t := range in.PreferredDuringSchedulingIgnoredDuringExecution {
+			preferred[i] = map[string]interface{}{
+				"weight":     int(t.Weight),
+				"preference": []interface{}{flattenNodeSelectorTerm(t.Preference)},
+			}
+		}
+		att["preferred_during_scheduling_ignored_during_execution"] = preferred
+	}
+	return []interface{}{att}
+}
+
+func flattenNodeSelectorTerm(in v1.NodeSelectorTerm) map[string]interface{} {
+	exprs := make([]interface{}, len(in.MatchExpressions))
+	for i, e := range in.MatchExpressions {
+		exprs[i] = map[string]interface{}{
+			"key":      e.Key,
+			"operator": string(e.Operator),
+			"values":   newStringSet(schema.HashString, e.Values),
+		}
+	}
+	return map[string]interface{}{
+		"match_expressions": exprs,
+	}
+}
+
+func flattenPodAffinityTerms(required []v1.PodAffinityTerm, preferred []v1.WeightedPodAffinityTerm) []interface{} {
+	att := make(map[string]interface{})
+	if len(required) > 0 {
+		terms := make([]interface{}, len(required))
+		for i, t := range required {
+			terms[i] = flattenPodAffinityTerm(t)
+		}
+		att["required_during_scheduling_ignored_during_execution"] = terms
+	}
+	if len(preferred) > 0 {
+		terms := make([]interface{}, len(preferred))
+		for i, t := range preferred {
+			terms[i] = map[string]interface{}{
+				"weight":            int(t.Weight),
+				"pod_affinity_term": []interface{}{flattenPodAffinityTerm(t.PodAffinityTerm)},
+			}
+		}
+		att["preferred_during_scheduling_ignored_during_execution"] = terms
+	}
+	return []interface{}{att}
+}
+
+func flattenPodAffinityTerm(in v1.PodAffinityTerm) map[string]interface{} {
+	att := map[string]interface{}{
+		"topology_key": in.TopologyKey,
+	}
+	if in.LabelSelector != nil {
+		att["label_selector"] = flattenLabelSelector(in.LabelSelector)
+	}
+	if len(in.Namespaces) > 0 {
+		att["namespaces"] = newStringSet(schema.HashString, in.Namespaces)
+	}
+	return att
+}
+
+// Expanders
+
+func expandAffinity(l []interface{}) *v1.Affinity {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.Affinity{}
+	if v, ok := in["node_affinity"].([]interface{}); ok && len(v) > 0 {
+		obj.NodeAffinity = expandNodeAffinity(v)
+	}
+	if v, ok := in["pod_affinity"].([]interface{}); ok && len(v) > 0 {
+		required, preferred := expandPodAffinityTerms(v)
+		obj.PodAffinity = &v1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	if v, ok := in["pod_anti_affinity"].([]interface{}); ok && len(v) > 0 {
+		required, preferred := expandPodAffinityTerms(v)
+		obj.PodAntiAffinity = &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution:  required,
+			PreferredDuringSchedulingIgnoredDuringExecution: preferred,
+		}
+	}
+	return obj
+}
+
+func expandNodeAffinity(l []interface{}) *v1.NodeAffinity {
+	obj := &v1.NodeAffinity{}
+	if l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["required_during_scheduling_ignored_during_execution"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		terms := v[0].(map[string]interface{})["node_selector_term"].([]interface{})
+		selector := &v1.NodeSelector{
+			NodeSelectorTerms: make([]v1.NodeSelectorTerm, len(terms)),
+		}
+		for i, t := range terms {
+			selector.NodeSelectorTerms[i] = expandNodeSelectorTerm(t)
+		}
+		obj.RequiredDuringSchedulingIgnoredDuringExecution = selector
+	}
+	if v, ok := in["preferred_during_scheduling_ignored_during_execution"].([]interface{}); ok && len(v) > 0 {
+		obj.PreferredDuringSchedulingIgnoredDuringExecution = make([]v1.PreferredSchedulingTerm, len(v))
+		for i, t := range v {
+			p := t.(map[string]interface{})
+			obj.PreferredDuringSchedulingIgnoredDuringExecution[i] = v1.PreferredSchedulingTerm{
+				Weight:     int32(p["weight"].(int)),
+				Preference: expandNodeSelectorTerm(p["preference"].([]interface{})[0]),
+			}
+		}
+	}
+	return obj
+}
+
+func expandNodeSelectorTerm(t interface{}) v1.NodeSelectorTerm {
+	obj := v1.NodeSelectorTerm{
+		MatchExpressions: []v1.NodeSelectorRequirement{},
+	}
+	in, ok := t.(map[string]interface{})
+	if !ok {
+		return obj
+	}
+	for _, e := range in["match_expressions"].([]interface{}) {
+		expr := e.(map[string]interface{})
+		req := v1.NodeSelectorRequirement{
+			Key:      expr["key"].(string),
+			Operator: v1.NodeSelectorOperator(expr["operator"].(string)),
+		}
+		if v, ok := expr["values"].(*schema.Set); ok && v.Len() > 0 {
+			req.Values = sliceOfString(v.List())
+		}
+		obj.MatchExpressions = append(obj.MatchExpressions, req)
+	}
+	return obj
+}
+
+func expandPodAffinityTerms(l []interface{}) ([]v1.PodAffinityTerm, []v1.WeightedPodAffinityTerm) {
+	if l[0] == nil {
+		return nil, nil
+	}
+	in := l[0].(map[string]interface{})
+	var required []v1.PodAffinityTerm
+	if v, ok := in["required_during_scheduling_ignored_during_execution"].([]interface{}); ok && len(v) > 0 {
+		required = make([]v1.PodAffinityTerm, len(v))
+		for i, t := range v {
+			required[i] = expandPodAffinityTerm(t)
+		}
+	}
+	var preferred []v1.WeightedPodAffinityTerm
+	if v, ok := in["preferred_during_scheduling_ignored_during_execution"].([]interface{}); ok && len(v) > 0 {
+		preferred = make([]v1.WeightedPodAffinityTerm, len(v))
+		for i, t := range v {
+			p := t.(map[string]interface{})
+			preferred[i] = v1.WeightedPodAffinityTerm{
+				Weight:          int32(p["weight"].(int)),
+				PodAffinityTerm: expandPodAffinityTerm(p["pod_affinity_term"].([]interface{})[0]),
+			}
+		}
+	}
+	return required, preferred
+}
+
+func expandPodAffinityTerm(t interface{}) v1.PodAffinityTerm {
+	in, ok := t.(map[string]interface{})
+	if !ok {
+		return v1.PodAffinityTerm{}
+	}
+	obj := v1.PodAffinityTerm{
+		TopologyKey: in["topology_key"].(string),
+	}
+	if v, ok := in["label_selector"].([]interface{}); ok && len(v) > 0 {
+		obj.LabelSelector = expandLabelSelector(v)
+	}
+	if v, ok := in["namespaces"].(*schema.Set); ok && v.Len() > 0 {
+		obj.Namespaces = sliceOfString(v.List())
+	}
+	return obj
+}
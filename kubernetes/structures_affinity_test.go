@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+func TestExpandFlattenAffinity(t *testing.T) {
+	in := &v1.Affinity{
+		NodeAffinity: &v1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+				NodeSelectorTerms: []v1.NodeSelectorTerm{
+					{
+						MatchExpressions: []v1.NodeSelectorRequirement{
+							{Key: "kubernetes.io/arch", Operator: v1.NodeSelectorOpIn, Values: []string{"amd64"}},
+						},
+					},
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.PreferredSchedulingTerm{
+				{
+					Weight: 10,
+					Preference: v1.NodeSelectorTerm{
+						MatchExpressions: []v1.NodeSelectorRequirement{
+							{Key: "disk", Operator: v1.NodeSelectorOpExists},
+						},
+					},
+				},
+			},
+		},
+		PodAntiAffinity: &v1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: v1.PodAffinityTerm{
+						LabelSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "web"},
+						},
+						TopologyKey: "kubernetes.io/hostname",
+					},
+				},
+			},
+		},
+	}
+
+	// Round trip through the schema, as the flattened values are typed differently
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"affinity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     affinitySchema(),
+			},
+		},
+	}
+	d := r.TestResourceData()
+	err := d.Set("affinity", flattenAffinity(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Get("affinity.0.pod_affinity.#").(int) != 0 {
+		t.Fatalf("Expected no pod affinity to be flattened: %#v", d.Get("affinity"))
+	}
+
+	out := expandAffinity(d.Get("affinity").([]interface{}))
+	if !reflect.DeepEqual(in, out) {
+		t.Fatalf("Affinity didn't survive flattening and expanding.\nExpected: %#v\nGiven: %#v", in, out)
+	}
+}
+
+func TestExpandAffinity_namespaces(t *testing.T) {
+	out := expandAffinity([]interface{}{
+		map[string]interface{}{
+			"pod_affinity": []interface{}{
+				map[string]interface{}{
+					"required_during_scheduling_ignored_during_execution": []interface{}{
+						map[string]interface{}{
+							"label_selector": []interface{}{},
+							"namespaces":     schema.NewSet(schema.HashString, []interface{}{"cache"}),
+							"topology_key":   "topology.kubernetes.io/zone",
+						},
+					},
+				},
+			},
+		},
+	})
+
+	terms := out.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 || terms[0].TopologyKey != "topology.kubernetes.io/zone" || !reflect.DeepEqual(terms[0].Namespaces, []string{"cache"}) {
+		t.Fatalf("Unexpected pod affinity terms: %#v", terms)
+	}
+	if terms[0].LabelSelector != nil {
+		t.Fatalf("Expected no label selector, got %#v", terms[0].LabelSelector)
+	}
+}
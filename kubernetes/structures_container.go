@@ -1,12 +1,74 @@
 package kubernetes
 
 import (
+	"fmt"
 	"strconv"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/kubernetes/pkg/api/v1"
 )
 
+// resourceEphemeralStorage isn't defined by the vendored API version yet,
+// but resource lists are plain maps and the API server accepts it
+const resourceEphemeralStorage = v1.ResourceName("ephemeral-storage")
+
+// container is a container including fields which the vendored API types predate
+type container struct {
+	v1.Container
+	LivenessProbe  *probe `json:"livenessProbe,omitempty"`
+	ReadinessProbe *probe `json:"readinessProbe,omitempty"`
+	StartupProbe   *probe `json:"startupProbe,omitempty"`
+	// RestartPolicy of init containers, Always makes them sidecars
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+
+	SecurityContext *securityContext `json:"securityContext,omitempty"`
+
+	Lifecycle *lifecycle `json:"lifecycle,omitempty"`
+}
+
+// lifecycle is a lifecycle including handlers which the vendored API types predate
+type lifecycle struct {
+	PostStart *lifecycleHandler `json:"postStart,omitempty"`
+	PreStop   *lifecycleHandler `json:"preStop,omitempty"`
+}
+
+type lifecycleHandler struct {
+	v1.Handler
+	Sleep *sleepAction `json:"sleep,omitempty"`
+}
+
+type sleepAction struct {
+	Seconds int64 `json:"seconds"`
+}
+
+// probe is a probe including handlers which the vendored API types predate
+type probe struct {
+	v1.Probe
+	GRPC *grpcAction `json:"grpc,omitempty"`
+}
+
+type grpcAction struct {
+	Port    int32   `json:"port"`
+	Service *string `json:"service,omitempty"`
+}
+
+// securityContext is a container security context including fields which
+// the vendored API types predate
+type securityContext struct {
+	v1.SecurityContext
+	RunAsGroup               *int64           `json:"runAsGroup,omitempty"`
+	AllowPrivilegeEscalation *bool            `json:"allowPrivilegeEscalation,omitempty"`
+	SeccompProfile           *securityProfile `json:"seccompProfile,omitempty"`
+	AppArmorProfile          *securityProfile `json:"appArmorProfile,omitempty"`
+}
+
+// securityProfile is a seccomp or AppArmor profile, both have the same fields
+type securityProfile struct {
+	Type             string  `json:"type"`
+	LocalhostProfile *string `json:"localhostProfile,omitempty"`
+}
+
 func flattenCapability(in []v1.Capability) []string {
 	att := make([]string, len(in), len(in))
 	for i, v := range in {
@@ -15,7 +77,7 @@ func flattenCapability(in []v1.Capability) []string {
 	return att
 }
 
-func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
+func flattenContainerSecurityContext(in *securityContext) []interface{} {
 	att := make(map[string]interface{})
 
 	if in.Privileged != nil {
@@ -31,6 +93,12 @@ func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
 	if in.RunAsUser != nil {
 		att["run_as_user"] = *in.RunAsUser
 	}
+	if in.RunAsGroup != nil {
+		att["run_as_group"] = *in.RunAsGroup
+	}
+	if in.AllowPrivilegeEscalation != nil {
+		att["allow_privilege_escalation"] = *in.AllowPrivilegeEscalation
+	}
 
 	if in.SELinuxOptions != nil {
 		att["se_linux_options"] = flattenSeLinuxOptions(in.SELinuxOptions)
@@ -38,10 +106,26 @@ func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
 	if in.Capabilities != nil {
 		att["capabilities"] = flattenSecurityCapabilities(in.Capabilities)
 	}
+	if in.SeccompProfile != nil {
+		att["seccomp_profile"] = flattenSecurityProfile(in.SeccompProfile)
+	}
+	if in.AppArmorProfile != nil {
+		att["app_armor_profile"] = flattenSecurityProfile(in.AppArmorProfile)
+	}
 	return []interface{}{att}
 
 }
 
+func flattenSecurityProfile(in *securityProfile) []interface{} {
+	att := map[string]interface{}{
+		"type": in.Type,
+	}
+	if in.LocalhostProfile != nil {
+		att["localhost_profile"] = *in.LocalhostProfile
+	}
+	return []interface{}{att}
+}
+
 func flattenSecurityCapabilities(in *v1.Capabilities) []interface{} {
 	att := make(map[string]interface{})
 
@@ -134,20 +218,34 @@ func flattenExec(in *v1.ExecAction) []interface{} {
 	return []interface{}{att}
 }
 
-func flattenLifeCycle(in *v1.Lifecycle) []interface{} {
+func flattenLifeCycle(in *lifecycle) []interface{} {
 	att := make(map[string]interface{})
 
 	if in.PostStart != nil {
-		att["post_start"] = flattenHandler(in.PostStart)
+		att["post_start"] = flattenLifecycleHandler(in.PostStart)
 	}
 	if in.PreStop != nil {
-		att["pre_stop"] = flattenHandler(in.PreStop)
+		att["pre_stop"] = flattenLifecycleHandler(in.PreStop)
 	}
 
 	return []interface{}{att}
 }
 
-func flattenProbe(in *v1.Probe) []interface{} {
+func flattenLifecycleHandler(in *lifecycleHandler) []interface{} {
+	out := flattenHandler(&in.Handler)
+	if in.Sleep != nil {
+		out[0].(map[string]interface{})["sleep"] = flattenSleep(in.Sleep)
+	}
+	return out
+}
+
+func flattenSleep(in *sleepAction) []interface{} {
+	att := make(map[string]interface{})
+	att["seconds"] = int(in.Seconds)
+	return []interface{}{att}
+}
+
+func flattenProbe(in *probe) []interface{} {
 	att := make(map[string]interface{})
 
 	att["failure_threshold"] = in.FailureThreshold
@@ -165,10 +263,22 @@ func flattenProbe(in *v1.Probe) []interface{} {
 	if in.TCPSocket != nil {
 		att["tcp_socket"] = flattenTCPSocket(in.TCPSocket)
 	}
+	if in.GRPC != nil {
+		att["grpc"] = flattenGRPC(in.GRPC)
+	}
 
 	return []interface{}{att}
 }
 
+func flattenGRPC(in *grpcAction) []interface{} {
+	att := make(map[string]interface{})
+	att["port"] = in.Port
+	if in.Service != nil {
+		att["service"] = *in.Service
+	}
+	return []interface{}{att}
+}
+
 func flattenConfigMapKeyRef(in *v1.ConfigMapKeySelector) []interface{} {
 	att := make(map[string]interface{})
 
@@ -178,6 +288,9 @@ func flattenConfigMapKeyRef(in *v1.ConfigMapKeySelector) []interface{} {
 	if in.Name != "" {
 		att["name"] = in.Name
 	}
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
 	return []interface{}{att}
 }
 
@@ -202,6 +315,10 @@ func flattenResourceFieldSelector(in *v1.ResourceFieldSelector) []interface{} {
 	if in.Resource != "" {
 		att["resource"] = in.Resource
 	}
+	// The API returns "0" when unset
+	if !in.Divisor.IsZero() {
+		att["divisor"] = in.Divisor.String()
+	}
 	return []interface{}{att}
 }
 
@@ -214,6 +331,9 @@ func flattenSecretKeyRef(in *v1.SecretKeySelector) []interface{} {
 	if in.Name != "" {
 		att["name"] = in.Name
 	}
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
 	return []interface{}{att}
 }
 
@@ -276,6 +396,34 @@ func flattenContainerEnvs(in []v1.EnvVar) []interface{} {
 	return att
 }
 
+func flattenContainerEnvFroms(in []v1.EnvFromSource) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		m := map[string]interface{}{}
+		if v.Prefix != "" {
+			m["prefix"] = v.Prefix
+		}
+		if v.ConfigMapRef != nil {
+			m["config_map_ref"] = flattenEnvSourceRef(v.ConfigMapRef.Name, v.ConfigMapRef.Optional)
+		}
+		if v.SecretRef != nil {
+			m["secret_ref"] = flattenEnvSourceRef(v.SecretRef.Name, v.SecretRef.Optional)
+		}
+		att[i] = m
+	}
+	return att
+}
+
+func flattenEnvSourceRef(name string, optional *bool) []interface{} {
+	att := map[string]interface{}{
+		"name": name,
+	}
+	if optional != nil {
+		att["optional"] = *optional
+	}
+	return []interface{}{att}
+}
+
 func flattenContainerPorts(in []v1.ContainerPort) []interface{} {
 	att := make([]interface{}, len(in))
 	for i, v := range in {
@@ -299,15 +447,24 @@ func flattenContainerPorts(in []v1.ContainerPort) []interface{} {
 func flattenContainerResourceRequirements(in v1.ResourceRequirements) ([]interface{}, error) {
 	att := make(map[string]interface{})
 	if len(in.Limits) > 0 {
-		att["limits"] = []interface{}{flattenResourceList(in.Limits)}
+		att["limits"] = []interface{}{flattenContainerResourceList(in.Limits)}
 	}
 	if len(in.Requests) > 0 {
-		att["requests"] = []interface{}{flattenResourceList(in.Requests)}
+		att["requests"] = []interface{}{flattenContainerResourceList(in.Requests)}
 	}
 	return []interface{}{att}, nil
 }
 
-func flattenContainers(in []v1.Container) ([]interface{}, error) {
+func flattenContainerResourceList(in v1.ResourceList) map[string]string {
+	m := flattenResourceList(in)
+	if v, ok := m[string(resourceEphemeralStorage)]; ok {
+		m["ephemeral_storage"] = v
+		delete(m, string(resourceEphemeralStorage))
+	}
+	return m
+}
+
+func flattenContainers(in []container) ([]interface{}, error) {
 	att := make([]interface{}, len(in))
 	for i, v := range in {
 		c := make(map[string]interface{})
@@ -338,9 +495,15 @@ func flattenContainers(in []v1.Container) ([]interface{}, error) {
 		if v.ReadinessProbe != nil {
 			c["readiness_probe"] = flattenProbe(v.ReadinessProbe)
 		}
+		if v.StartupProbe != nil {
+			c["startup_probe"] = flattenProbe(v.StartupProbe)
+		}
 		if v.Lifecycle != nil {
 			c["lifecycle"] = flattenLifeCycle(v.Lifecycle)
 		}
+		if v.RestartPolicy != "" {
+			c["restart_policy"] = v.RestartPolicy
+		}
 
 		if v.SecurityContext != nil {
 			c["security_context"] = flattenContainerSecurityContext(v.SecurityContext)
@@ -351,6 +514,9 @@ func flattenContainers(in []v1.Container) ([]interface{}, error) {
 		if len(v.Env) > 0 {
 			c["env"] = flattenContainerEnvs(v.Env)
 		}
+		if len(v.EnvFrom) > 0 {
+			c["env_from"] = flattenContainerEnvFroms(v.EnvFrom)
+		}
 
 		if len(v.VolumeMounts) > 0 {
 			volumeMounts, err := flattenContainerVolumeMounts(v.VolumeMounts)
@@ -364,11 +530,11 @@ func flattenContainers(in []v1.Container) ([]interface{}, error) {
 	return att, nil
 }
 
-func expandContainers(ctrs []interface{}) ([]v1.Container, error) {
+func expandContainers(ctrs []interface{}) ([]container, error) {
 	if len(ctrs) == 0 {
-		return []v1.Container{}, nil
+		return []container{}, nil
 	}
-	cs := make([]v1.Container, len(ctrs))
+	cs := make([]container, len(ctrs))
 	for i, c := range ctrs {
 		ctr := c.(map[string]interface{})
 
@@ -408,6 +574,9 @@ func expandContainers(ctrs []interface{}) ([]v1.Container, error) {
 				return cs, err
 			}
 		}
+		if v, ok := ctr["env_from"].([]interface{}); ok && len(v) > 0 {
+			cs[i].EnvFrom = expandContainerEnvFrom(v)
+		}
 
 		if policy, ok := ctr["image_pull_policy"]; ok {
 			cs[i].ImagePullPolicy = v1.PullPolicy(policy.(string))
@@ -424,6 +593,13 @@ func expandContainers(ctrs []interface{}) ([]v1.Container, error) {
 		if v, ok := ctr["readiness_probe"].([]interface{}); ok && len(v) > 0 {
 			cs[i].ReadinessProbe = expandProbe(v)
 		}
+
+		if v, ok := ctr["startup_probe"].([]interface{}); ok && len(v) > 0 {
+			cs[i].StartupProbe = expandProbe(v)
+		}
+		if v, ok := ctr["restart_policy"].(string); ok {
+			cs[i].RestartPolicy = v
+		}
 		if v, ok := ctr["stdin"]; ok {
 			cs[i].Stdin = v.(bool)
 		}
@@ -479,12 +655,12 @@ func expandHTTPHeaders(l []interface{}) []v1.HTTPHeader {
 	}
 	return headers
 }
-func expandContainerSecurityContext(l []interface{}) *v1.SecurityContext {
+func expandContainerSecurityContext(l []interface{}) *securityContext {
 	if len(l) == 0 || l[0] == nil {
-		return &v1.SecurityContext{}
+		return &securityContext{}
 	}
 	in := l[0].(map[string]interface{})
-	obj := v1.SecurityContext{}
+	obj := securityContext{}
 	if v, ok := in["privileged"]; ok {
 		obj.Privileged = ptrToBool(v.(bool))
 	}
@@ -494,8 +670,17 @@ func expandContainerSecurityContext(l []interface{}) *v1.SecurityContext {
 	if v, ok := in["run_as_non_root"]; ok {
 		obj.RunAsNonRoot = ptrToBool(v.(bool))
 	}
-	if v, ok := in["run_as_user"]; ok {
-		obj.RunAsUser = ptrToInt64(int64(v.(int)))
+	// Unset IDs read as 0, see expandPodSecurityContext
+	if v, ok := in["run_as_user"].(int); ok && v > 0 {
+		obj.RunAsUser = ptrToInt64(int64(v))
+	}
+	if v, ok := in["run_as_group"].(int); ok && v > 0 {
+		obj.RunAsGroup = ptrToInt64(int64(v))
+	}
+	// Unset reads as false, which privileged containers mustn't send,
+	// see expandExplicitZeroValues
+	if v, ok := in["allow_privilege_escalation"].(bool); ok && v {
+		obj.AllowPrivilegeEscalation = ptrToBool(v)
 	}
 	if v, ok := in["se_linux_options"].([]interface{}); ok && len(v) > 0 {
 		obj.SELinuxOptions = expandSeLinuxOptions(v)
@@ -503,10 +688,30 @@ func expandContainerSecurityContext(l []interface{}) *v1.SecurityContext {
 	if v, ok := in["capabilities"].([]interface{}); ok && len(v) > 0 {
 		obj.Capabilities = expandSecurityCapabilities(v)
 	}
+	if v, ok := in["seccomp_profile"].([]interface{}); ok && len(v) > 0 {
+		obj.SeccompProfile = expandSecurityProfile(v)
+	}
+	if v, ok := in["app_armor_profile"].([]interface{}); ok && len(v) > 0 {
+		obj.AppArmorProfile = expandSecurityProfile(v)
+	}
 
 	return &obj
 }
 
+func expandSecurityProfile(l []interface{}) *securityProfile {
+	if len(l) == 0 || l[0] == nil {
+		return &securityProfile{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := &securityProfile{
+		Type: in["type"].(string),
+	}
+	if v, ok := in["localhost_profile"].(string); ok && v != "" {
+		obj.LocalhostProfile = &v
+	}
+	return obj
+}
+
 func expandCapabilitySlice(s []interface{}) []v1.Capability {
 	result := make([]v1.Capability, len(s), len(s))
 	for k, v := range s {
@@ -568,12 +773,12 @@ func expandHTTPGet(l []interface{}) *v1.HTTPGetAction {
 	return &obj
 }
 
-func expandProbe(l []interface{}) *v1.Probe {
+func expandProbe(l []interface{}) *probe {
 	if len(l) == 0 || l[0] == nil {
-		return &v1.Probe{}
+		return &probe{}
 	}
 	in := l[0].(map[string]interface{})
-	obj := v1.Probe{}
+	obj := probe{}
 	if v, ok := in["exec"].([]interface{}); ok && len(v) > 0 {
 		obj.Exec = expandExec(v)
 	}
@@ -583,6 +788,9 @@ func expandProbe(l []interface{}) *v1.Probe {
 	if v, ok := in["tcp_socket"].([]interface{}); ok && len(v) > 0 {
 		obj.TCPSocket = expandTCPSocket(v)
 	}
+	if v, ok := in["grpc"].([]interface{}); ok && len(v) > 0 {
+		obj.GRPC = expandGRPC(v)
+	}
 	if v, ok := in["failure_threshold"].(int); ok {
 		obj.FailureThreshold = int32(v)
 	}
@@ -602,6 +810,21 @@ func expandProbe(l []interface{}) *v1.Probe {
 	return &obj
 }
 
+func expandGRPC(l []interface{}) *grpcAction {
+	if len(l) == 0 || l[0] == nil {
+		return &grpcAction{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := grpcAction{}
+	if v, ok := in["port"].(int); ok {
+		obj.Port = int32(v)
+	}
+	if v, ok := in["service"].(string); ok && v != "" {
+		obj.Service = &v
+	}
+	return &obj
+}
+
 func expandHandlers(l []interface{}) *v1.Handler {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.Handler{}
@@ -620,21 +843,45 @@ func expandHandlers(l []interface{}) *v1.Handler {
 	return &obj
 
 }
-func expandLifeCycle(l []interface{}) *v1.Lifecycle {
+func expandLifeCycle(l []interface{}) *lifecycle {
 	if len(l) == 0 || l[0] == nil {
-		return &v1.Lifecycle{}
+		return &lifecycle{}
 	}
 	in := l[0].(map[string]interface{})
-	obj := &v1.Lifecycle{}
+	obj := &lifecycle{}
 	if v, ok := in["post_start"].([]interface{}); ok && len(v) > 0 {
-		obj.PostStart = expandHandlers(v)
+		obj.PostStart = expandLifecycleHandler(v)
 	}
 	if v, ok := in["pre_stop"].([]interface{}); ok && len(v) > 0 {
-		obj.PreStop = expandHandlers(v)
+		obj.PreStop = expandLifecycleHandler(v)
 	}
 	return obj
 }
 
+func expandLifecycleHandler(l []interface{}) *lifecycleHandler {
+	obj := &lifecycleHandler{Handler: *expandHandlers(l)}
+	if l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+	if v, ok := in["sleep"].([]interface{}); ok && len(v) > 0 {
+		obj.Sleep = expandSleep(v)
+	}
+	return obj
+}
+
+func expandSleep(l []interface{}) *sleepAction {
+	if len(l) == 0 || l[0] == nil {
+		return &sleepAction{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := sleepAction{}
+	if v, ok := in["seconds"].(int); ok {
+		obj.Seconds = int64(v)
+	}
+	return &obj
+}
+
 func expandContainerVolumeMounts(in []interface{}) ([]v1.VolumeMount, error) {
 	if len(in) == 0 {
 		return []v1.VolumeMount{}, nil
@@ -658,6 +905,38 @@ func expandContainerVolumeMounts(in []interface{}) ([]v1.VolumeMount, error) {
 	return vmp, nil
 }
 
+func expandContainerEnvFrom(in []interface{}) []v1.EnvFromSource {
+	envFroms := make([]v1.EnvFromSource, len(in))
+	for i, c := range in {
+		p, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := p["prefix"].(string); ok {
+			envFroms[i].Prefix = v
+		}
+		if v, ok := p["config_map_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ref := v[0].(map[string]interface{})
+			envFroms[i].ConfigMapRef = &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: ref["name"].(string)},
+			}
+			if optional, ok := ref["optional"].(bool); ok && optional {
+				envFroms[i].ConfigMapRef.Optional = ptrToBool(optional)
+			}
+		}
+		if v, ok := p["secret_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ref := v[0].(map[string]interface{})
+			envFroms[i].SecretRef = &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: ref["name"].(string)},
+			}
+			if optional, ok := ref["optional"].(bool); ok && optional {
+				envFroms[i].SecretRef.Optional = ptrToBool(optional)
+			}
+		}
+	}
+	return envFroms
+}
+
 func expandContainerEnv(in []interface{}) ([]v1.EnvVar, error) {
 	if len(in) == 0 {
 		return []v1.EnvVar{}, nil
@@ -721,6 +1000,9 @@ func expandConfigMapKeyRef(r []interface{}) (*v1.ConfigMapKeySelector, error) {
 	if v, ok := in["name"].(string); ok {
 		obj.Name = v
 	}
+	if v, ok := in["optional"].(bool); ok && v {
+		obj.Optional = ptrToBool(v)
+	}
 	return obj, nil
 
 }
@@ -752,6 +1034,13 @@ func expandResourceFieldRef(r []interface{}) (*v1.ResourceFieldSelector, error)
 	if v, ok := in["resource"].(string); ok {
 		obj.Resource = v
 	}
+	if v, ok := in["divisor"].(string); ok && v != "" {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return obj, fmt.Errorf("%s for %q", err, v)
+		}
+		obj.Divisor = q
+	}
 	return obj, nil
 }
 func expandSecretKeyRef(r []interface{}) (*v1.SecretKeySelector, error) {
@@ -767,6 +1056,9 @@ func expandSecretKeyRef(r []interface{}) (*v1.SecretKeySelector, error) {
 	if v, ok := in["name"].(string); ok {
 		obj.Name = v
 	}
+	if v, ok := in["optional"].(bool); ok && v {
+		obj.Optional = ptrToBool(v)
+	}
 	return obj, nil
 }
 
@@ -822,6 +1114,12 @@ func expandContainerResourceRequirements(l []interface{}) (v1.ResourceRequiremen
 			if p["memory"] == "" {
 				delete(p, "memory")
 			}
+			if v, ok := p["ephemeral_storage"]; ok {
+				if v != "" {
+					p[string(resourceEphemeralStorage)] = v
+				}
+				delete(p, "ephemeral_storage")
+			}
 			return expandMapToResourceList(p)
 		}
 		return nil, nil
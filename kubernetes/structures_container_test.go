@@ -0,0 +1,419 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+func TestExpandContainerResourceRequirements(t *testing.T) {
+	cases := []struct {
+		Input          []interface{}
+		ExpectedOutput v1.ResourceRequirements
+	}{
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"limits": []interface{}{
+						map[string]interface{}{
+							"cpu":               "500m",
+							"memory":            "",
+							"ephemeral_storage": "1Gi",
+						},
+					},
+					"requests": []interface{}{
+						map[string]interface{}{
+							"cpu":               "",
+							"memory":            "",
+							"ephemeral_storage": "",
+						},
+					},
+				},
+			},
+			v1.ResourceRequirements{
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:           resource.MustParse("500m"),
+					resourceEphemeralStorage: resource.MustParse("1Gi"),
+				},
+				Requests: v1.ResourceList{},
+			},
+		},
+		{
+			[]interface{}{},
+			v1.ResourceRequirements{},
+		},
+	}
+
+	for _, tc := range cases {
+		output, err := expandContainerResourceRequirements(tc.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(output, tc.ExpectedOutput) {
+			t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v",
+				tc.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestFlattenContainerResourceRequirements(t *testing.T) {
+	in := v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceMemory:        resource.MustParse("512Mi"),
+			resourceEphemeralStorage: resource.MustParse("2Gi"),
+		},
+	}
+	expected := []interface{}{
+		map[string]interface{}{
+			"limits": []interface{}{
+				map[string]string{
+					"memory":            "512Mi",
+					"ephemeral_storage": "2Gi",
+				},
+			},
+		},
+	}
+
+	output, err := flattenContainerResourceRequirements(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Fatalf("Unexpected output from flattener.\nExpected: %#v\nGiven:    %#v",
+			expected, output)
+	}
+}
+
+func TestExpandFlattenEnvValueFrom(t *testing.T) {
+	cases := []struct {
+		Input          []interface{}
+		ExpectedOutput v1.EnvVarSource
+	}{
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"secret_key_ref": []interface{}{
+						map[string]interface{}{
+							"name":     "db",
+							"key":      "password",
+							"optional": true,
+						},
+					},
+				},
+			},
+			v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "db"},
+					Key:                  "password",
+					Optional:             ptrToBool(true),
+				},
+			},
+		},
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"config_map_key_ref": []interface{}{
+						map[string]interface{}{
+							"name": "settings",
+							"key":  "log_level",
+						},
+					},
+				},
+			},
+			v1.EnvVarSource{
+				ConfigMapKeyRef: &v1.ConfigMapKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "settings"},
+					Key:                  "log_level",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		output, err := expandEnvValueFrom(tc.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*output, tc.ExpectedOutput) {
+			t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v", tc.ExpectedOutput, *output)
+		}
+		flattened := flattenValueFrom(output)
+		if !reflect.DeepEqual(flattened, tc.Input) {
+			t.Fatalf("Unexpected output from flattener.\nExpected: %#v\nGiven:    %#v", tc.Input, flattened)
+		}
+	}
+}
+
+func TestExpandContainerSecurityContext_runAsNonRoot(t *testing.T) {
+	out := expandContainerSecurityContext([]interface{}{
+		map[string]interface{}{
+			"privileged":                false,
+			"read_only_root_filesystem": true,
+			"run_as_non_root":           true,
+			"run_as_user":               0,
+			"se_linux_options":          []interface{}{},
+			"capabilities":              []interface{}{},
+		},
+	})
+	// An unset user must be left to the image, root would fail run_as_non_root
+	if out.RunAsUser != nil {
+		t.Fatalf("Expected no user, got %d", *out.RunAsUser)
+	}
+	if out.RunAsNonRoot == nil || !*out.RunAsNonRoot || out.ReadOnlyRootFilesystem == nil || !*out.ReadOnlyRootFilesystem {
+		t.Fatalf("Unexpected security context: %#v", out)
+	}
+
+	pod := expandPodSecurityContext([]interface{}{
+		map[string]interface{}{
+			"fs_group":        0,
+			"run_as_non_root": true,
+			"run_as_user":     1000,
+		},
+	})
+	if pod.FSGroup != nil || pod.RunAsUser == nil || *pod.RunAsUser != 1000 {
+		t.Fatalf("Unexpected pod security context: %#v", pod)
+	}
+}
+
+func TestExpandFlattenSecurityContextFields(t *testing.T) {
+	ctr := expandContainerSecurityContext([]interface{}{
+		map[string]interface{}{
+			"allow_privilege_escalation": true,
+			"run_as_group":               3000,
+		},
+	})
+	raw, err := json.Marshal(container{SecurityContext: ctr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"runAsGroup":3000,"allowPrivilegeEscalation":true`) {
+		t.Fatalf("Expected the container fields to be sent, got %s", raw)
+	}
+	flattened := flattenContainerSecurityContext(ctr)[0].(map[string]interface{})
+	if flattened["run_as_group"] != int64(3000) || flattened["allow_privilege_escalation"] != true {
+		t.Fatalf("Unexpected flattened container security context: %#v", flattened)
+	}
+
+	pod := expandPodSecurityContext([]interface{}{
+		map[string]interface{}{
+			"fs_group":               2000,
+			"fs_group_change_policy": "OnRootMismatch",
+			"run_as_group":           3000,
+		},
+	})
+	raw, err = json.Marshal(podSpec{SecurityContext: pod})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"runAsGroup":3000,"fsGroupChangePolicy":"OnRootMismatch"`) {
+		t.Fatalf("Expected the pod fields to be sent, got %s", raw)
+	}
+	flattenedPod := flattenPodSecurityContext(pod)[0].(map[string]interface{})
+	if flattenedPod["run_as_group"] != int64(3000) || flattenedPod["fs_group_change_policy"] != "OnRootMismatch" {
+		t.Fatalf("Unexpected flattened pod security context: %#v", flattenedPod)
+	}
+}
+
+func TestExpandFlattenSecurityProfiles(t *testing.T) {
+	profiles := map[string]interface{}{
+		"seccomp_profile": []interface{}{
+			map[string]interface{}{"type": "RuntimeDefault", "localhost_profile": ""},
+		},
+		"app_armor_profile": []interface{}{
+			map[string]interface{}{"type": "Localhost", "localhost_profile": "k8s-nginx"},
+		},
+	}
+
+	ctr := expandContainerSecurityContext([]interface{}{profiles})
+	raw, err := json.Marshal(container{SecurityContext: ctr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `"securityContext":{"seccompProfile":{"type":"RuntimeDefault"},"appArmorProfile":{"type":"Localhost","localhostProfile":"k8s-nginx"}}`
+	if !strings.Contains(string(raw), expected) {
+		t.Fatalf("Expected the container profiles to be sent, got %s", raw)
+	}
+	flattened := flattenContainerSecurityContext(ctr)[0].(map[string]interface{})
+	if !reflect.DeepEqual(flattened["app_armor_profile"], profiles["app_armor_profile"]) {
+		t.Fatalf("Unexpected flattened app_armor_profile: %#v", flattened["app_armor_profile"])
+	}
+	// An empty localhost_profile is left out, it's only set for Localhost
+	if seccomp := flattened["seccomp_profile"].([]interface{})[0].(map[string]interface{}); seccomp["type"] != "RuntimeDefault" {
+		t.Fatalf("Unexpected flattened seccomp_profile: %#v", seccomp)
+	}
+
+	pod := expandPodSecurityContext([]interface{}{profiles})
+	raw, err = json.Marshal(podSpec{SecurityContext: pod})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), expected) {
+		t.Fatalf("Expected the pod profiles to be sent, got %s", raw)
+	}
+	if len(flattenPodSecurityContext(pod)) != 1 {
+		t.Fatalf("Expected a security context with only profiles to be flattened")
+	}
+}
+
+func TestExpandFlattenContainerEnvFrom(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"prefix": "APP_",
+			"config_map_ref": []interface{}{
+				map[string]interface{}{
+					"name":     "settings",
+					"optional": false,
+				},
+			},
+			"secret_ref": []interface{}{},
+		},
+		map[string]interface{}{
+			"prefix":         "",
+			"config_map_ref": []interface{}{},
+			"secret_ref": []interface{}{
+				map[string]interface{}{
+					"name":     "credentials",
+					"optional": true,
+				},
+			},
+		},
+	}
+	expected := []v1.EnvFromSource{
+		{
+			Prefix: "APP_",
+			ConfigMapRef: &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "settings"},
+			},
+		},
+		{
+			SecretRef: &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "credentials"},
+				Optional:             ptrToBool(true),
+			},
+		},
+	}
+
+	output := expandContainerEnvFrom(in)
+	if !reflect.DeepEqual(output, expected) {
+		t.Fatalf("Unexpected output from expander.\nExpected: %#v\nGiven:    %#v", expected, output)
+	}
+
+	flattened := flattenContainerEnvFroms(output)
+	if flattened[0].(map[string]interface{})["prefix"] != "APP_" {
+		t.Fatalf("Unexpected output from flattener: %#v", flattened)
+	}
+	ref := flattened[1].(map[string]interface{})["secret_ref"].([]interface{})[0].(map[string]interface{})
+	if ref["name"] != "credentials" || ref["optional"] != true {
+		t.Fatalf("Unexpected secret ref: %#v", ref)
+	}
+}
+
+func TestExpandFlattenResourceFieldRef(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"container_name": "app",
+			"resource":       "limits.memory",
+			"divisor":        "1Mi",
+		},
+	}
+	out, err := expandResourceFieldRef(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.ContainerName != "app" || out.Resource != "limits.memory" || out.Divisor.String() != "1Mi" {
+		t.Fatalf("Unexpected resource field ref: %#v", out)
+	}
+	flattened := flattenResourceFieldSelector(out)[0].(map[string]interface{})
+	if flattened["divisor"] != "1Mi" {
+		t.Fatalf("Unexpected flattened resource field ref: %#v", flattened)
+	}
+
+	// The API returns a zero divisor when it's unset
+	flattened = flattenResourceFieldSelector(&v1.ResourceFieldSelector{Resource: "limits.cpu", Divisor: resource.MustParse("0")})[0].(map[string]interface{})
+	if _, ok := flattened["divisor"]; ok {
+		t.Fatalf("Expected no divisor, got %#v", flattened)
+	}
+
+	in[0].(map[string]interface{})["divisor"] = "one"
+	_, err = expandResourceFieldRef(in)
+	if err == nil {
+		t.Fatal("Expected an invalid divisor to fail")
+	}
+}
+
+func TestExpandFlattenLifeCycle_sleep(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"pre_stop": []interface{}{
+				map[string]interface{}{
+					"sleep": []interface{}{
+						map[string]interface{}{
+							"seconds": 15,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	out := expandLifeCycle(in)
+	if out.PostStart != nil || out.PreStop == nil || out.PreStop.Sleep == nil || out.PreStop.Sleep.Seconds != 15 {
+		t.Fatalf("Unexpected lifecycle: %#v", out)
+	}
+
+	raw, err := json.Marshal(container{Lifecycle: out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `"lifecycle":{"preStop":{"sleep":{"seconds":15}}}`
+	if !strings.Contains(string(raw), expected) {
+		t.Fatalf("Expected %s, got %s", expected, raw)
+	}
+
+	flattened := flattenLifeCycle(out)
+	if !reflect.DeepEqual(flattened, in) {
+		t.Fatalf("Unexpected flattened lifecycle: %#v", flattened)
+	}
+}
+
+func TestExpandFlattenProbe_grpc(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"grpc": []interface{}{
+				map[string]interface{}{
+					"port":    9090,
+					"service": "api",
+				},
+			},
+			"failure_threshold":     3,
+			"initial_delay_seconds": 0,
+			"period_seconds":        10,
+			"success_threshold":     1,
+			"timeout_seconds":       1,
+		},
+	}
+
+	out := expandProbe(in)
+	if out.GRPC == nil || out.GRPC.Port != 9090 || out.GRPC.Service == nil || *out.GRPC.Service != "api" {
+		t.Fatalf("Unexpected gRPC handler: %#v", out.GRPC)
+	}
+
+	raw, err := json.Marshal(container{LivenessProbe: out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `"livenessProbe":{`
+	if !strings.Contains(string(raw), expected) || !strings.Contains(string(raw), `"grpc":{"port":9090,"service":"api"}`) {
+		t.Fatalf("Expected a gRPC liveness probe, got %s", raw)
+	}
+
+	flattened := flattenProbe(out)
+	grpc := flattened[0].(map[string]interface{})["grpc"].([]interface{})[0].(map[string]interface{})
+	if grpc["port"] != int32(9090) || grpc["service"] != "api" {
+		t.Fatalf("Unexpected flattened gRPC handler: %#v", grpc)
+	}
+}
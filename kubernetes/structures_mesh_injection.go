@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"sort"
+)
+
+type meshInjectionAnnotation struct {
+	Key      string
+	Enabled  string
+	Disabled string
+}
+
+var meshInjectionAnnotations = map[string]meshInjectionAnnotation{
+	"istio":   {Key: "sidecar.istio.io/inject", Enabled: "true", Disabled: "false"},
+	"linkerd": {Key: "linkerd.io/inject", Enabled: "enabled", Disabled: "disabled"},
+}
+
+func meshInjectionProviders() []string {
+	providers := make([]string, 0, len(meshInjectionAnnotations))
+	for p := range meshInjectionAnnotations {
+		providers = append(providers, p)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// expandMeshInjection merges the injection annotation described by the
+// mesh_injection block into the given annotations
+func expandMeshInjection(l []interface{}, annotations map[string]string) map[string]string {
+	if len(l) == 0 || l[0] == nil {
+		return annotations
+	}
+	in := l[0].(map[string]interface{})
+	a := meshInjectionAnnotations[in["provider"].(string)]
+
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if in["enabled"].(bool) {
+		annotations[a.Key] = a.Enabled
+	} else {
+		annotations[a.Key] = a.Disabled
+	}
+	return annotations
+}
+
+// flattenMeshInjection reads the mesh_injection block back from annotations
+// and removes the injection annotation so it does not show up in metadata
+func flattenMeshInjection(annotations map[string]string) []interface{} {
+	for _, p := range meshInjectionProviders() {
+		a := meshInjectionAnnotations[p]
+		v, ok := annotations[a.Key]
+		if !ok {
+			continue
+		}
+		delete(annotations, a.Key)
+		return []interface{}{map[string]interface{}{
+			"provider": p,
+			"enabled":  v == a.Enabled,
+		}}
+	}
+	return []interface{}{}
+}
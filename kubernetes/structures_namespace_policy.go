@@ -0,0 +1,69 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api "k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
+)
+
+func namespacePolicyTier(d *schema.ResourceData) (map[string]interface{}, error) {
+	name := d.Get("tier").(string)
+	for _, t := range d.Get("tier_limits").([]interface{}) {
+		tier := t.(map[string]interface{})
+		if tier["name"].(string) == name {
+			return tier, nil
+		}
+	}
+	return nil, fmt.Errorf("Tier %q not found in tier_limits", name)
+}
+
+func expandNamespacePolicyQuotaSpec(tier map[string]interface{}) (api.ResourceQuotaSpec, error) {
+	obj := api.ResourceQuotaSpec{}
+	hard, err := expandMapToResourceList(tier["quota"].(map[string]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Hard = hard
+	return obj, nil
+}
+
+func expandNamespacePolicyLimitRangeSpec(tier map[string]interface{}) (api.LimitRangeSpec, error) {
+	obj := api.LimitRangeSpec{}
+	def, err := expandMapToResourceList(tier["default_limit"].(map[string]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	defRequest, err := expandMapToResourceList(tier["default_request"].(map[string]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Limits = []api.LimitRangeItem{
+		{
+			Type:           api.LimitTypeContainer,
+			Default:        def,
+			DefaultRequest: defRequest,
+		},
+	}
+	return obj, nil
+}
+
+// expandNamespacePolicyNetworkPolicy builds a policy selecting all pods in the namespace
+// which only allows ingress from pods within the same namespace
+func expandNamespacePolicyNetworkPolicy(metadata metav1.ObjectMeta) v1beta1.NetworkPolicy {
+	return v1beta1.NetworkPolicy{
+		ObjectMeta: metadata,
+		Spec: v1beta1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			Ingress: []v1beta1.NetworkPolicyIngressRule{
+				{
+					From: []v1beta1.NetworkPolicyPeer{
+						{PodSelector: &metav1.LabelSelector{}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"k8s.io/kubernetes/pkg/api/v1"
+)
+
+func flattenNodeSpec(in v1.NodeSpec, taints []v1.Taint) []interface{} {
+	att := make(map[string]interface{})
+	att["unschedulable"] = in.Unschedulable
+
+	ts := make([]interface{}, len(taints))
+	for i, t := range taints {
+		ts[i] = map[string]interface{}{
+			"key":    t.Key,
+			"value":  t.Value,
+			"effect": string(t.Effect),
+		}
+	}
+	att["taint"] = ts
+
+	return []interface{}{att}
+}
+
+func expandNodeSpec(l []interface{}) v1.NodeSpec {
+	obj := v1.NodeSpec{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	obj.Unschedulable = in["unschedulable"].(bool)
+	for _, t := range in["taint"].([]interface{}) {
+		m := t.(map[string]interface{})
+		obj.Taints = append(obj.Taints, v1.Taint{
+			Key:    m["key"].(string),
+			Value:  m["value"].(string),
+			Effect: v1.TaintEffect(m["effect"].(string)),
+		})
+	}
+
+	return obj
+}
+
+// mergeStringMaps removes previously managed keys from the current map
+// and sets the newly managed ones, leaving all other keys untouched
+func mergeStringMaps(current, prev, next map[string]string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range current {
+		if _, ok := prev[k]; ok {
+			continue
+		}
+		out[k] = v
+	}
+	for k, v := range next {
+		out[k] = v
+	}
+	return out
+}
+
+// mergeNodeTaints is mergeStringMaps for taints, keyed by key and effect
+func mergeNodeTaints(current, prev, next []v1.Taint) []v1.Taint {
+	out := make([]v1.Taint, 0)
+	for _, t := range current {
+		if findNodeTaint(prev, t.Key, t.Effect) != nil || findNodeTaint(next, t.Key, t.Effect) != nil {
+			continue
+		}
+		out = append(out, t)
+	}
+	return append(out, next...)
+}
+
+func filterStringMap(m map[string]string, keys map[string]interface{}) map[string]string {
+	out := make(map[string]string)
+	for k := range keys {
+		if v, ok := m[k]; ok {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -1,25 +1,120 @@
 package kubernetes
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/kubernetes/pkg/api/v1"
 )
 
+// osNodeSelectorKey is the node label holding the operating system of the node
+const osNodeSelectorKey = "kubernetes.io/os"
+
+// podSpec is a pod spec including fields which the vendored API types predate.
+// Pods and templates embedding it are sent and read through raw REST requests,
+// the typed clients would drop these fields.
+type podSpec struct {
+	v1.PodSpec
+	InitContainers []container `json:"initContainers,omitempty"`
+	Containers     []container `json:"containers"`
+
+	SecurityContext *podSecurityContext `json:"securityContext,omitempty"`
+
+	TopologySpreadConstraints []topologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	Volumes []volume `json:"volumes,omitempty"`
+}
+
+// volume is a volume including sources which the vendored API types predate
+type volume struct {
+	v1.Volume
+	CSI       *csiVolumeSource       `json:"csi,omitempty"`
+	Ephemeral *ephemeralVolumeSource `json:"ephemeral,omitempty"`
+	Projected *projectedVolumeSource `json:"projected,omitempty"`
+}
+
+// projectedVolumeSource is a projected volume including sources which the
+// vendored API types predate
+type projectedVolumeSource struct {
+	Sources     []volumeProjection `json:"sources"`
+	DefaultMode *int32             `json:"defaultMode,omitempty"`
+}
+
+type volumeProjection struct {
+	v1.VolumeProjection
+	ServiceAccountToken *serviceAccountTokenProjection `json:"serviceAccountToken,omitempty"`
+}
+
+// serviceAccountTokenProjection is the Kubernetes 1.12 ServiceAccountTokenProjection
+type serviceAccountTokenProjection struct {
+	Audience          string `json:"audience,omitempty"`
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+	Path              string `json:"path"`
+}
+
+// csiVolumeSource is the Kubernetes 1.16 CSIVolumeSource
+type csiVolumeSource struct {
+	Driver               string                   `json:"driver"`
+	ReadOnly             *bool                    `json:"readOnly,omitempty"`
+	FSType               *string                  `json:"fsType,omitempty"`
+	VolumeAttributes     map[string]string        `json:"volumeAttributes,omitempty"`
+	NodePublishSecretRef *v1.LocalObjectReference `json:"nodePublishSecretRef,omitempty"`
+}
+
+// ephemeralVolumeSource is the Kubernetes 1.23 EphemeralVolumeSource
+type ephemeralVolumeSource struct {
+	VolumeClaimTemplate *persistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+}
+
+type persistentVolumeClaimTemplate struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              v1.PersistentVolumeClaimSpec `json:"spec"`
+}
+
+// podSecurityContext is a pod security context including fields which the
+// vendored API types predate
+type podSecurityContext struct {
+	v1.PodSecurityContext
+	RunAsGroup          *int64           `json:"runAsGroup,omitempty"`
+	FSGroupChangePolicy *string          `json:"fsGroupChangePolicy,omitempty"`
+	SeccompProfile      *securityProfile `json:"seccompProfile,omitempty"`
+	AppArmorProfile     *securityProfile `json:"appArmorProfile,omitempty"`
+}
+
+// topologySpreadConstraint is the Kubernetes 1.19 TopologySpreadConstraint
+type topologySpreadConstraint struct {
+	MaxSkew           int32                 `json:"maxSkew"`
+	TopologyKey       string                `json:"topologyKey"`
+	WhenUnsatisfiable string                `json:"whenUnsatisfiable"`
+	LabelSelector     *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	MinDomains        *int32                `json:"minDomains,omitempty"`
+}
+
 // Flatteners
 
-func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
+func flattenPodSpec(in podSpec) ([]interface{}, error) {
 	att := make(map[string]interface{})
 	if in.ActiveDeadlineSeconds != nil {
 		att["active_deadline_seconds"] = *in.ActiveDeadlineSeconds
 	}
+	if in.Affinity != nil {
+		att["affinity"] = flattenAffinity(in.Affinity)
+	}
 	containers, err := flattenContainers(in.Containers)
 	if err != nil {
 		return nil, err
 	}
 	att["container"] = containers
 
+	initContainers, err := flattenContainers(in.InitContainers)
+	if err != nil {
+		return nil, err
+	}
+	att["init_container"] = initContainers
+
 	att["dns_policy"] = in.DNSPolicy
 
 	att["host_ipc"] = in.HostIPC
@@ -37,6 +132,9 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 	if len(in.NodeSelector) > 0 {
 		att["node_selector"] = in.NodeSelector
 	}
+	if os, ok := in.NodeSelector[osNodeSelectorKey]; ok {
+		att["os"] = os
+	}
 	if in.RestartPolicy != "" {
 		att["restart_policy"] = in.RestartPolicy
 	}
@@ -55,6 +153,9 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 		att["termination_grace_period_seconds"] = *in.TerminationGracePeriodSeconds
 	}
 
+	att["toleration"] = flattenTolerations(in.Tolerations)
+	att["topology_spread_constraint"] = flattenTopologySpreadConstraints(in.TopologySpreadConstraints)
+
 	if len(in.Volumes) > 0 {
 		v, err := flattenVolumes(in.Volumes)
 		if err != nil {
@@ -65,11 +166,17 @@ func flattenPodSpec(in v1.PodSpec) ([]interface{}, error) {
 	return []interface{}{att}, nil
 }
 
-func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
+func flattenPodSecurityContext(in *podSecurityContext) []interface{} {
 	att := make(map[string]interface{})
 	if in.FSGroup != nil {
 		att["fs_group"] = *in.FSGroup
 	}
+	if in.FSGroupChangePolicy != nil {
+		att["fs_group_change_policy"] = *in.FSGroupChangePolicy
+	}
+	if in.RunAsGroup != nil {
+		att["run_as_group"] = *in.RunAsGroup
+	}
 
 	if in.RunAsNonRoot != nil {
 		att["run_as_non_root"] = *in.RunAsNonRoot
@@ -87,6 +194,12 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 	if in.SELinuxOptions != nil {
 		att["se_linux_options"] = flattenSeLinuxOptions(in.SELinuxOptions)
 	}
+	if in.SeccompProfile != nil {
+		att["seccomp_profile"] = flattenSecurityProfile(in.SeccompProfile)
+	}
+	if in.AppArmorProfile != nil {
+		att["app_armor_profile"] = flattenSecurityProfile(in.AppArmorProfile)
+	}
 
 	if len(att) > 0 {
 		return []interface{}{att}
@@ -94,6 +207,58 @@ func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
 	return []interface{}{}
 }
 
+// isDefaultToleration tells whether the toleration is one of those which the
+// DefaultTolerationSeconds admission plugin adds to every pod
+func isDefaultToleration(in v1.Toleration) bool {
+	switch in.Key {
+	case "node.kubernetes.io/not-ready", "node.kubernetes.io/unreachable",
+		"node.alpha.kubernetes.io/notReady", "node.alpha.kubernetes.io/unreachable":
+	default:
+		return false
+	}
+	return in.Operator == v1.TolerationOpExists && in.Effect == v1.TaintEffectNoExecute &&
+		in.TolerationSeconds != nil && *in.TolerationSeconds == 300
+}
+
+func flattenTolerations(in []v1.Toleration) []interface{} {
+	att := make([]interface{}, 0, len(in))
+	for _, v := range in {
+		if isDefaultToleration(v) {
+			continue
+		}
+		m := map[string]interface{}{
+			"effect":   string(v.Effect),
+			"key":      v.Key,
+			"operator": string(v.Operator),
+			"value":    v.Value,
+		}
+		if v.TolerationSeconds != nil {
+			m["toleration_seconds"] = int(*v.TolerationSeconds)
+		}
+		att = append(att, m)
+	}
+	return att
+}
+
+func flattenTopologySpreadConstraints(in []topologySpreadConstraint) []interface{} {
+	att := make([]interface{}, len(in), len(in))
+	for i, v := range in {
+		m := map[string]interface{}{
+			"max_skew":           int(v.MaxSkew),
+			"topology_key":       v.TopologyKey,
+			"when_unsatisfiable": v.WhenUnsatisfiable,
+		}
+		if v.LabelSelector != nil {
+			m["label_selector"] = flattenLabelSelector(v.LabelSelector)
+		}
+		if v.MinDomains != nil {
+			m["min_domains"] = int(*v.MinDomains)
+		}
+		att[i] = m
+	}
+	return att
+}
+
 func flattenSeLinuxOptions(in *v1.SELinuxOptions) []interface{} {
 	att := make(map[string]interface{})
 	if in.User != "" {
@@ -102,7 +267,7 @@ func flattenSeLinuxOptions(in *v1.SELinuxOptions) []interface{} {
 	if in.Role != "" {
 		att["role"] = in.Role
 	}
-	if in.User != "" {
+	if in.Type != "" {
 		att["type"] = in.Type
 	}
 	if in.Level != "" {
@@ -111,7 +276,7 @@ func flattenSeLinuxOptions(in *v1.SELinuxOptions) []interface{} {
 	return []interface{}{att}
 }
 
-func flattenVolumes(volumes []v1.Volume) ([]interface{}, error) {
+func flattenVolumes(volumes []volume) ([]interface{}, error) {
 	att := make([]interface{}, len(volumes))
 	for i, v := range volumes {
 		obj := map[string]interface{}{}
@@ -188,11 +353,60 @@ func flattenVolumes(volumes []v1.Volume) ([]interface{}, error) {
 		if v.PhotonPersistentDisk != nil {
 			obj["photon_persistent_disk"] = flattenPhotonPersistentDiskVolumeSource(v.PhotonPersistentDisk)
 		}
+		if v.Projected != nil {
+			obj["projected"] = flattenProjectedVolumeSource(v.Projected)
+		}
+		if v.CSI != nil {
+			obj["csi"] = flattenCSIVolumeSource(v.CSI)
+		}
+		if v.Ephemeral != nil {
+			obj["ephemeral"] = flattenEphemeralVolumeSource(v.Ephemeral)
+		}
 		att[i] = obj
 	}
 	return att, nil
 }
 
+func flattenCSIVolumeSource(in *csiVolumeSource) []interface{} {
+	att := map[string]interface{}{
+		"driver": in.Driver,
+	}
+	if in.ReadOnly != nil {
+		att["read_only"] = *in.ReadOnly
+	}
+	if in.FSType != nil {
+		att["fs_type"] = *in.FSType
+	}
+	if len(in.VolumeAttributes) > 0 {
+		att["volume_attributes"] = in.VolumeAttributes
+	}
+	if in.NodePublishSecretRef != nil {
+		att["node_publish_secret_ref"] = []interface{}{
+			map[string]interface{}{"name": in.NodePublishSecretRef.Name},
+		}
+	}
+	return []interface{}{att}
+}
+
+func flattenEphemeralVolumeSource(in *ephemeralVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if t := in.VolumeClaimTemplate; t != nil {
+		template := map[string]interface{}{
+			"spec": flattenPersistentVolumeClaimSpec(t.Spec),
+		}
+		if len(t.Labels) > 0 || len(t.Annotations) > 0 {
+			template["metadata"] = []interface{}{
+				map[string]interface{}{
+					"annotations": t.Annotations,
+					"labels":      t.Labels,
+				},
+			}
+		}
+		att["volume_claim_template"] = []interface{}{template}
+	}
+	return []interface{}{att}
+}
+
 func flattenPersistentVolumeClaimVolumeSource(in *v1.PersistentVolumeClaimVolumeSource) []interface{} {
 	att := make(map[string]interface{})
 	if in.ClaimName != "" {
@@ -221,7 +435,7 @@ func flattenGitRepoVolumeSource(in *v1.GitRepoVolumeSource) []interface{} {
 func flattenDownwardAPIVolumeSource(in *v1.DownwardAPIVolumeSource) []interface{} {
 	att := make(map[string]interface{})
 	if in.DefaultMode != nil {
-		att["default_mode"] = in.DefaultMode
+		att["default_mode"] = *in.DefaultMode
 	}
 	if len(in.Items) > 0 {
 		att["items"] = flattenDownwardAPIVolumeFile(in.Items)
@@ -250,6 +464,71 @@ func flattenDownwardAPIVolumeFile(in []v1.DownwardAPIVolumeFile) []interface{} {
 	return att
 }
 
+func flattenProjectedVolumeSource(in *projectedVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.DefaultMode != nil {
+		att["default_mode"] = *in.DefaultMode
+	}
+	sources := make([]interface{}, len(in.Sources))
+	for i, s := range in.Sources {
+		m := map[string]interface{}{}
+		if s.ConfigMap != nil {
+			m["config_map"] = flattenKeyToPathProjection(s.ConfigMap.Name, s.ConfigMap.Optional, s.ConfigMap.Items)
+		}
+		if s.DownwardAPI != nil {
+			m["downward_api"] = []interface{}{
+				map[string]interface{}{
+					"items": flattenDownwardAPIVolumeFile(s.DownwardAPI.Items),
+				},
+			}
+		}
+		if s.Secret != nil {
+			m["secret"] = flattenKeyToPathProjection(s.Secret.Name, s.Secret.Optional, s.Secret.Items)
+		}
+		if s.ServiceAccountToken != nil {
+			m["service_account_token"] = flattenServiceAccountTokenProjection(s.ServiceAccountToken)
+		}
+		sources[i] = m
+	}
+	att["sources"] = sources
+	return []interface{}{att}
+}
+
+func flattenServiceAccountTokenProjection(in *serviceAccountTokenProjection) []interface{} {
+	att := map[string]interface{}{
+		"audience": in.Audience,
+		"path":     in.Path,
+	}
+	if in.ExpirationSeconds != nil {
+		att["expiration_seconds"] = int(*in.ExpirationSeconds)
+	}
+	return []interface{}{att}
+}
+
+func flattenKeyToPathProjection(name string, optional *bool, in []v1.KeyToPath) []interface{} {
+	att := map[string]interface{}{
+		"name": name,
+	}
+	if optional != nil {
+		att["optional"] = *optional
+	}
+	if len(in) > 0 {
+		items := make([]interface{}, len(in))
+		for i, v := range in {
+			m := map[string]interface{}{
+				"key":  v.Key,
+				"path": v.Path,
+			}
+			if v.Mode != nil {
+				m["mode"] = int(*v.Mode)
+			}
+			items[i] = m
+		}
+		att["items"] = items
+	}
+	return []interface{}{att}
+}
+
 func flattenConfigMapVolumeSource(in *v1.ConfigMapVolumeSource) []interface{} {
 	att := make(map[string]interface{})
 	if in.DefaultMode != nil {
@@ -261,7 +540,9 @@ func flattenConfigMapVolumeSource(in *v1.ConfigMapVolumeSource) []interface{} {
 		for i, v := range in.Items {
 			m := map[string]interface{}{}
 			m["key"] = v.Key
-			m["mode"] = v.Mode
+			if v.Mode != nil {
+				m["mode"] = *v.Mode
+			}
 			m["path"] = v.Path
 			items[i] = m
 		}
@@ -306,8 +587,8 @@ func flattenSecretVolumeSource(in *v1.SecretVolumeSource) []interface{} {
 
 // Expanders
 
-func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
-	obj := v1.PodSpec{}
+func expandPodSpec(p []interface{}) (podSpec, error) {
+	obj := podSpec{}
 	if len(p) == 0 || p[0] == nil {
 		return obj, nil
 	}
@@ -317,6 +598,10 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.ActiveDeadlineSeconds = ptrToInt64(int64(v))
 	}
 
+	if v, ok := in["affinity"].([]interface{}); ok && len(v) > 0 {
+		obj.Affinity = expandAffinity(v)
+	}
+
 	if v, ok := in["container"].([]interface{}); ok && len(v) > 0 {
 		cs, err := expandContainers(v)
 		if err != nil {
@@ -325,6 +610,22 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.Containers = cs
 	}
 
+	if v, ok := in["init_container"].([]interface{}); ok && len(v) > 0 {
+		cs, err := expandContainers(v)
+		if err != nil {
+			return obj, err
+		}
+		for _, c := range cs {
+			if c.RestartPolicy == "Always" {
+				continue
+			}
+			if c.Lifecycle != nil || c.LivenessProbe != nil || c.ReadinessProbe != nil || c.StartupProbe != nil {
+				return obj, fmt.Errorf("init_container %q can only have lifecycle hooks and probes as a sidecar, with restart_policy = %q", c.Name, "Always")
+			}
+		}
+		obj.InitContainers = cs
+	}
+
 	if v, ok := in["dns_policy"].(string); ok {
 		obj.DNSPolicy = v1.DNSPolicy(v)
 	}
@@ -364,6 +665,16 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.NodeSelector = nodeSelectors
 	}
 
+	if v, ok := in["os"].(string); ok && v != "" {
+		if os, ok := obj.NodeSelector[osNodeSelectorKey]; ok && os != v {
+			return obj, fmt.Errorf("node_selector %s = %q conflicts with os = %q", osNodeSelectorKey, os, v)
+		}
+		if obj.NodeSelector == nil {
+			obj.NodeSelector = make(map[string]string)
+		}
+		obj.NodeSelector[osNodeSelectorKey] = v
+	}
+
 	if v, ok := in["restart_policy"].(string); ok {
 		obj.RestartPolicy = v1.RestartPolicy(v)
 	}
@@ -384,6 +695,14 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		obj.TerminationGracePeriodSeconds = ptrToInt64(int64(v))
 	}
 
+	if v, ok := in["toleration"].([]interface{}); ok && len(v) > 0 {
+		obj.Tolerations = expandTolerations(v)
+	}
+
+	if v, ok := in["topology_spread_constraint"].([]interface{}); ok && len(v) > 0 {
+		obj.TopologySpreadConstraints = expandTopologySpreadConstraints(v)
+	}
+
 	if v, ok := in["volume"].([]interface{}); ok && len(v) > 0 {
 		cs, err := expandVolumes(v)
 		if err != nil {
@@ -391,22 +710,117 @@ func expandPodSpec(p []interface{}) (v1.PodSpec, error) {
 		}
 		obj.Volumes = cs
 	}
+
+	if obj.NodeSelector[osNodeSelectorKey] == "windows" {
+		err := validateWindowsPodSpec(obj)
+		if err != nil {
+			return obj, err
+		}
+	}
 	return obj, nil
 }
 
-func expandPodSecurityContext(l []interface{}) *v1.PodSecurityContext {
+// validateWindowsPodSpec rejects fields which Windows nodes don't support,
+// such pods would otherwise fail only once they're scheduled
+func validateWindowsPodSpec(spec podSpec) error {
+	unsupported := make([]string, 0)
+	if spec.HostIPC {
+		unsupported = append(unsupported, "host_ipc")
+	}
+	if spec.HostNetwork {
+		unsupported = append(unsupported, "host_network")
+	}
+	if spec.HostPID {
+		unsupported = append(unsupported, "host_pid")
+	}
+	if sc := spec.SecurityContext; sc != nil {
+		if sc.FSGroup != nil {
+			unsupported = append(unsupported, "security_context.fs_group")
+		}
+		if sc.FSGroupChangePolicy != nil {
+			unsupported = append(unsupported, "security_context.fs_group_change_policy")
+		}
+		if sc.RunAsUser != nil {
+			unsupported = append(unsupported, "security_context.run_as_user")
+		}
+		if sc.RunAsGroup != nil {
+			unsupported = append(unsupported, "security_context.run_as_group")
+		}
+		if len(sc.SupplementalGroups) > 0 {
+			unsupported = append(unsupported, "security_context.supplemental_groups")
+		}
+		if sc.SELinuxOptions != nil {
+			unsupported = append(unsupported, "security_context.se_linux_options")
+		}
+		if sc.SeccompProfile != nil {
+			unsupported = append(unsupported, "security_context.seccomp_profile")
+		}
+		if sc.AppArmorProfile != nil {
+			unsupported = append(unsupported, "security_context.app_armor_profile")
+		}
+	}
+	for _, c := range append(spec.InitContainers, spec.Containers...) {
+		sc := c.SecurityContext
+		if sc == nil {
+			continue
+		}
+		prefix := fmt.Sprintf("container %q security_context.", c.Name)
+		if sc.Privileged != nil && *sc.Privileged {
+			unsupported = append(unsupported, prefix+"privileged")
+		}
+		if sc.ReadOnlyRootFilesystem != nil && *sc.ReadOnlyRootFilesystem {
+			unsupported = append(unsupported, prefix+"read_only_root_filesystem")
+		}
+		if sc.RunAsUser != nil {
+			unsupported = append(unsupported, prefix+"run_as_user")
+		}
+		if sc.RunAsGroup != nil {
+			unsupported = append(unsupported, prefix+"run_as_group")
+		}
+		if sc.AllowPrivilegeEscalation != nil {
+			unsupported = append(unsupported, prefix+"allow_privilege_escalation")
+		}
+		if sc.SELinuxOptions != nil {
+			unsupported = append(unsupported, prefix+"se_linux_options")
+		}
+		if sc.Capabilities != nil && (len(sc.Capabilities.Add) > 0 || len(sc.Capabilities.Drop) > 0) {
+			unsupported = append(unsupported, prefix+"capabilities")
+		}
+		if sc.SeccompProfile != nil {
+			unsupported = append(unsupported, prefix+"seccomp_profile")
+		}
+		if sc.AppArmorProfile != nil {
+			unsupported = append(unsupported, prefix+"app_armor_profile")
+		}
+	}
+
+	if len(unsupported) > 0 {
+		return fmt.Errorf("Windows nodes don't support %s, set with os = %q", strings.Join(unsupported, ", "), "windows")
+	}
+	return nil
+}
+
+func expandPodSecurityContext(l []interface{}) *podSecurityContext {
 	if len(l) == 0 || l[0] == nil {
-		return &v1.PodSecurityContext{}
+		return &podSecurityContext{}
 	}
 	in := l[0].(map[string]interface{})
-	obj := &v1.PodSecurityContext{}
-	if v, ok := in["fs_group"].(int); ok {
+	obj := &podSecurityContext{}
+	// Unset IDs read as 0, which mustn't be sent as that would mean root
+	// and break run_as_non_root, see expandExplicitZeroValues
+	if v, ok := in["fs_group"].(int); ok && v > 0 {
 		obj.FSGroup = ptrToInt64(int64(v))
 	}
+	if v, ok := in["fs_group_change_policy"].(string); ok && v != "" {
+		obj.FSGroupChangePolicy = ptrToString(v)
+	}
+	if v, ok := in["run_as_group"].(int); ok && v > 0 {
+		obj.RunAsGroup = ptrToInt64(int64(v))
+	}
 	if v, ok := in["run_as_non_root"].(bool); ok {
 		obj.RunAsNonRoot = ptrToBool(v)
 	}
-	if v, ok := in["run_as_user"].(int); ok {
+	if v, ok := in["run_as_user"].(int); ok && v > 0 {
 		obj.RunAsUser = ptrToInt64(int64(v))
 	}
 	if v, ok := in["supplemental_groups"].(*schema.Set); ok {
@@ -416,10 +830,82 @@ func expandPodSecurityContext(l []interface{}) *v1.PodSecurityContext {
 	if v, ok := in["se_linux_options"].([]interface{}); ok && len(v) > 0 {
 		obj.SELinuxOptions = expandSeLinuxOptions(v)
 	}
+	if v, ok := in["seccomp_profile"].([]interface{}); ok && len(v) > 0 {
+		obj.SeccompProfile = expandSecurityProfile(v)
+	}
+	if v, ok := in["app_armor_profile"].([]interface{}); ok && len(v) > 0 {
+		obj.AppArmorProfile = expandSecurityProfile(v)
+	}
 
 	return obj
 }
 
+// expandExplicitZeroValues sets the user and group IDs configured as 0 and
+// allow_privilege_escalation configured as false under prefix, which the
+// expanders can't tell apart from unset ones
+func expandExplicitZeroValues(d *schema.ResourceData, prefix string, spec *podSpec) {
+	isZero := func(key string) bool {
+		v, ok := d.GetOkExists(prefix + key)
+		return ok && (v == 0 || v == false)
+	}
+	if spec.SecurityContext != nil {
+		if isZero("security_context.0.fs_group") {
+			spec.SecurityContext.FSGroup = ptrToInt64(0)
+		}
+		if isZero("security_context.0.run_as_user") {
+			spec.SecurityContext.RunAsUser = ptrToInt64(0)
+		}
+		if isZero("security_context.0.run_as_group") {
+			spec.SecurityContext.RunAsGroup = ptrToInt64(0)
+		}
+	}
+	for _, c := range []struct {
+		key        string
+		containers []container
+	}{
+		{"init_container", spec.InitContainers},
+		{"container", spec.Containers},
+	} {
+		for i := range c.containers {
+			sc := c.containers[i].SecurityContext
+			if sc == nil {
+				continue
+			}
+			key := fmt.Sprintf("%s.%d.security_context.0.", c.key, i)
+			if isZero(key + "run_as_user") {
+				sc.RunAsUser = ptrToInt64(0)
+			}
+			if isZero(key + "run_as_group") {
+				sc.RunAsGroup = ptrToInt64(0)
+			}
+			if isZero(key + "allow_privilege_escalation") {
+				sc.AllowPrivilegeEscalation = ptrToBool(false)
+			}
+		}
+	}
+}
+
+func expandTolerations(l []interface{}) []v1.Toleration {
+	obj := make([]v1.Toleration, 0, len(l))
+	for _, t := range l {
+		in, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		toleration := v1.Toleration{
+			Effect:   v1.TaintEffect(in["effect"].(string)),
+			Key:      in["key"].(string),
+			Operator: v1.TolerationOperator(in["operator"].(string)),
+			Value:    in["value"].(string),
+		}
+		if v, ok := in["toleration_seconds"].(int); ok && v > 0 {
+			toleration.TolerationSeconds = ptrToInt64(int64(v))
+		}
+		obj = append(obj, toleration)
+	}
+	return obj
+}
+
 func expandSeLinuxOptions(l []interface{}) *v1.SELinuxOptions {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.SELinuxOptions{}
@@ -451,7 +937,8 @@ func expandKeyPath(in []interface{}) []v1.KeyToPath {
 		if v, ok := p["key"].(string); ok {
 			keyPaths[i].Key = v
 		}
-		if v, ok := p["mode"].(int); ok {
+		// Unset modes read as 0, the volume's default mode applies then
+		if v, ok := p["mode"].(int); ok && v > 0 {
 			keyPaths[i].Mode = ptrToInt32(int32(v))
 		}
 		if v, ok := p["path"].(string); ok {
@@ -470,7 +957,8 @@ func expandDownwardAPIVolumeFile(in []interface{}) ([]v1.DownwardAPIVolumeFile,
 	dapivf := make([]v1.DownwardAPIVolumeFile, len(in))
 	for i, c := range in {
 		p := c.(map[string]interface{})
-		if v, ok := p["mode"].(int); ok {
+		// Unset modes read as 0, the volume's default mode applies then
+		if v, ok := p["mode"].(int); ok && v > 0 {
 			dapivf[i].Mode = ptrToInt32(int32(v))
 		}
 		if v, ok := p["path"].(string); ok {
@@ -492,6 +980,70 @@ func expandDownwardAPIVolumeFile(in []interface{}) ([]v1.DownwardAPIVolumeFile,
 	return dapivf, nil
 }
 
+func expandProjectedVolumeSource(l []interface{}) (*projectedVolumeSource, error) {
+	if len(l) == 0 || l[0] == nil {
+		return &projectedVolumeSource{}, nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &projectedVolumeSource{
+		DefaultMode: ptrToInt32(int32(in["default_mode"].(int))),
+		Sources:     []volumeProjection{},
+	}
+	sources, _ := in["sources"].([]interface{})
+	for _, s := range sources {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		projection := volumeProjection{}
+		if v, ok := m["config_map"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ref := v[0].(map[string]interface{})
+			projection.ConfigMap = &v1.ConfigMapProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: ref["name"].(string)},
+				Items:                expandKeyPath(ref["items"].([]interface{})),
+			}
+			if optional, ok := ref["optional"].(bool); ok && optional {
+				projection.ConfigMap.Optional = ptrToBool(optional)
+			}
+		}
+		if v, ok := m["downward_api"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			items, err := expandDownwardAPIVolumeFile(v[0].(map[string]interface{})["items"].([]interface{}))
+			if err != nil {
+				return obj, err
+			}
+			projection.DownwardAPI = &v1.DownwardAPIProjection{Items: items}
+		}
+		if v, ok := m["secret"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			ref := v[0].(map[string]interface{})
+			projection.Secret = &v1.SecretProjection{
+				LocalObjectReference: v1.LocalObjectReference{Name: ref["name"].(string)},
+				Items:                expandKeyPath(ref["items"].([]interface{})),
+			}
+			if optional, ok := ref["optional"].(bool); ok && optional {
+				projection.Secret.Optional = ptrToBool(optional)
+			}
+		}
+		if v, ok := m["service_account_token"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			projection.ServiceAccountToken = expandServiceAccountTokenProjection(v[0].(map[string]interface{}))
+		}
+		obj.Sources = append(obj.Sources, projection)
+	}
+	return obj, nil
+}
+
+func expandServiceAccountTokenProjection(in map[string]interface{}) *serviceAccountTokenProjection {
+	obj := &serviceAccountTokenProjection{
+		Path: in["path"].(string),
+	}
+	if v, ok := in["audience"].(string); ok {
+		obj.Audience = v
+	}
+	if v, ok := in["expiration_seconds"].(int); ok && v > 0 {
+		obj.ExpirationSeconds = ptrToInt64(int64(v))
+	}
+	return obj
+}
+
 func expandConfigMapVolumeSource(l []interface{}) *v1.ConfigMapVolumeSource {
 	if len(l) == 0 || l[0] == nil {
 		return &v1.ConfigMapVolumeSource{}
@@ -591,11 +1143,11 @@ func expandSecretVolumeSource(l []interface{}) *v1.SecretVolumeSource {
 	return obj
 }
 
-func expandVolumes(volumes []interface{}) ([]v1.Volume, error) {
+func expandVolumes(volumes []interface{}) ([]volume, error) {
 	if len(volumes) == 0 {
-		return []v1.Volume{}, nil
+		return []volume{}, nil
 	}
-	vl := make([]v1.Volume, len(volumes))
+	vl := make([]volume, len(volumes))
 	for i, c := range volumes {
 		m := c.(map[string]interface{})
 
@@ -678,10 +1230,80 @@ func expandVolumes(volumes []interface{}) ([]v1.Volume, error) {
 		if v, ok := m["photon_persistent_disk"].([]interface{}); ok && len(v) > 0 {
 			vl[i].PhotonPersistentDisk = expandPhotonPersistentDiskVolumeSource(v)
 		}
+		if v, ok := m["projected"].([]interface{}); ok && len(v) > 0 {
+			var err error
+			vl[i].Projected, err = expandProjectedVolumeSource(v)
+			if err != nil {
+				return vl, err
+			}
+		}
+		if v, ok := m["csi"].([]interface{}); ok && len(v) > 0 {
+			vl[i].CSI = expandCSIVolumeSource(v)
+		}
+		if v, ok := m["ephemeral"].([]interface{}); ok && len(v) > 0 {
+			var err error
+			vl[i].Ephemeral, err = expandEphemeralVolumeSource(v)
+			if err != nil {
+				return vl, err
+			}
+		}
 	}
 	return vl, nil
 }
 
+func expandCSIVolumeSource(l []interface{}) *csiVolumeSource {
+	if len(l) == 0 || l[0] == nil {
+		return &csiVolumeSource{}
+	}
+	in := l[0].(map[string]interface{})
+	obj := &csiVolumeSource{
+		Driver: in["driver"].(string),
+	}
+	if v, ok := in["read_only"].(bool); ok && v {
+		obj.ReadOnly = ptrToBool(v)
+	}
+	if v, ok := in["fs_type"].(string); ok && v != "" {
+		obj.FSType = ptrToString(v)
+	}
+	if v, ok := in["volume_attributes"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.VolumeAttributes = expandStringMap(v)
+	}
+	if v, ok := in["node_publish_secret_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		obj.NodePublishSecretRef = &v1.LocalObjectReference{
+			Name: v[0].(map[string]interface{})["name"].(string),
+		}
+	}
+	return obj
+}
+
+func expandEphemeralVolumeSource(l []interface{}) (*ephemeralVolumeSource, error) {
+	obj := &ephemeralVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+	t, ok := in["volume_claim_template"].([]interface{})
+	if !ok || len(t) == 0 || t[0] == nil {
+		return obj, nil
+	}
+	template := t[0].(map[string]interface{})
+	spec, err := expandPersistentVolumeClaimSpec(template["spec"].([]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.VolumeClaimTemplate = &persistentVolumeClaimTemplate{Spec: spec}
+	if m, ok := template["metadata"].([]interface{}); ok && len(m) > 0 && m[0] != nil {
+		meta := m[0].(map[string]interface{})
+		if v, ok := meta["annotations"].(map[string]interface{}); ok && len(v) > 0 {
+			obj.VolumeClaimTemplate.Annotations = expandStringMap(v)
+		}
+		if v, ok := meta["labels"].(map[string]interface{}); ok && len(v) > 0 {
+			obj.VolumeClaimTemplate.Labels = expandStringMap(v)
+		}
+	}
+	return obj, nil
+}
+
 func patchPodSpec(pathPrefix, prefix string, d *schema.ResourceData) (PatchOperations, error) {
 	ops := make([]PatchOperation, 0)
 
@@ -710,3 +1332,26 @@ func patchPodSpec(pathPrefix, prefix string, d *schema.ResourceData) (PatchOpera
 
 	return ops, nil
 }
+
+func expandTopologySpreadConstraints(l []interface{}) []topologySpreadConstraint {
+	obj := make([]topologySpreadConstraint, 0, len(l))
+	for _, c := range l {
+		in, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		constraint := topologySpreadConstraint{
+			MaxSkew:           int32(in["max_skew"].(int)),
+			TopologyKey:       in["topology_key"].(string),
+			WhenUnsatisfiable: in["when_unsatisfiable"].(string),
+		}
+		if v, ok := in["label_selector"].([]interface{}); ok && len(v) > 0 {
+			constraint.LabelSelector = expandLabelSelector(v)
+		}
+		if v, ok := in["min_domains"].(int); ok && v > 0 {
+			constraint.MinDomains = ptrToInt32(int32(v))
+		}
+		obj = append(obj, constraint)
+	}
+	return obj
+}
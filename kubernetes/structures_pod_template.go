@@ -0,0 +1,59 @@
+package kubernetes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podTemplateSpec is a pod template including the podSpec fields
+// which the vendored API types predate
+type podTemplateSpec struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              podSpec `json:"spec,omitempty"`
+}
+
+func flattenPodTemplateSpec(in podTemplateSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+
+	meta := make(map[string]interface{})
+	if annotations := removeInternalKeys(in.Annotations); len(annotations) > 0 {
+		meta["annotations"] = annotations
+	}
+	if labels := removeInternalKeys(in.Labels); len(labels) > 0 {
+		meta["labels"] = labels
+	}
+	if len(meta) > 0 {
+		att["metadata"] = []interface{}{meta}
+	}
+
+	podSpec, err := flattenPodSpec(in.Spec)
+	if err != nil {
+		return nil, err
+	}
+	att["spec"] = podSpec
+
+	return []interface{}{att}, nil
+}
+
+func expandPodTemplateSpec(t []interface{}) (podTemplateSpec, error) {
+	obj := podTemplateSpec{}
+	if len(t) == 0 || t[0] == nil {
+		return obj, nil
+	}
+	in := t[0].(map[string]interface{})
+
+	if m, ok := in["metadata"].([]interface{}); ok && len(m) > 0 && m[0] != nil {
+		meta := m[0].(map[string]interface{})
+		obj.ObjectMeta = metav1.ObjectMeta{
+			Annotations: expandStringMap(meta["annotations"].(map[string]interface{})),
+			Labels:      expandStringMap(meta["labels"].(map[string]interface{})),
+		}
+	}
+
+	podSpec, err := expandPodSpec(in["spec"].([]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Spec = podSpec
+
+	return obj, nil
+}
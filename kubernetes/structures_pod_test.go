@@ -0,0 +1,556 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/kubernetes/pkg/api/v1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestExpandPodSpec_os(t *testing.T) {
+	spec := func(os string, nodeSelector map[string]interface{}, hostNetwork bool) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"os":            os,
+				"node_selector": nodeSelector,
+				"host_network":  hostNetwork,
+				"container": []interface{}{
+					map[string]interface{}{
+						"name":  "app",
+						"image": "app:1.0",
+						"security_context": []interface{}{
+							map[string]interface{}{
+								"privileged": hostNetwork,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	out, err := expandPodSpec(spec("linux", map[string]interface{}{"disk": "ssd"}, true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.NodeSelector["kubernetes.io/os"] != "linux" || out.NodeSelector["disk"] != "ssd" {
+		t.Fatalf("Unexpected node selector: %#v", out.NodeSelector)
+	}
+
+	out, err = expandPodSpec(spec("windows", nil, false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.NodeSelector["kubernetes.io/os"] != "windows" {
+		t.Fatalf("Unexpected node selector: %#v", out.NodeSelector)
+	}
+
+	_, err = expandPodSpec(spec("windows", nil, true))
+	if err == nil {
+		t.Fatal("Expected host_network and privileged containers to be rejected on Windows")
+	}
+	for _, field := range []string{"host_network", `container "app" security_context.privileged`} {
+		if !strings.Contains(err.Error(), field) {
+			t.Fatalf("Expected %s to be rejected, got %q", field, err)
+		}
+	}
+
+	_, err = expandPodSpec(spec("windows", map[string]interface{}{"kubernetes.io/os": "linux"}, false))
+	if err == nil {
+		t.Fatal("Expected conflicting node selector to fail")
+	}
+}
+
+func TestExpandFlattenPodSpec_initContainers(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"init_container": []interface{}{
+				map[string]interface{}{
+					"name":    "migrate",
+					"image":   "app:1.0",
+					"command": []interface{}{"migrate", "up"},
+				},
+			},
+			"container": []interface{}{
+				map[string]interface{}{
+					"name":  "app",
+					"image": "app:1.0",
+				},
+			},
+		},
+	}
+
+	spec, err := expandPodSpec(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.InitContainers) != 1 || spec.InitContainers[0].Name != "migrate" || len(spec.InitContainers[0].Command) != 2 {
+		t.Fatalf("Unexpected init containers: %#v", spec.InitContainers)
+	}
+	if len(spec.Containers) != 1 || spec.Containers[0].Name != "app" {
+		t.Fatalf("Unexpected containers: %#v", spec.Containers)
+	}
+
+	out, err := flattenPodSpec(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initContainers := out[0].(map[string]interface{})["init_container"].([]interface{})
+	if len(initContainers) != 1 || initContainers[0].(map[string]interface{})["name"] != "migrate" {
+		t.Fatalf("Unexpected flattened init containers: %#v", initContainers)
+	}
+}
+
+func TestExpandFlattenPodSpec_sidecars(t *testing.T) {
+	spec := func(restartPolicy string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"init_container": []interface{}{
+					map[string]interface{}{
+						"name":           "log-forwarder",
+						"image":          "fluent-bit:2.2",
+						"restart_policy": restartPolicy,
+						"startup_probe": []interface{}{
+							map[string]interface{}{
+								"tcp_socket": []interface{}{
+									map[string]interface{}{"port": "2020"},
+								},
+							},
+						},
+					},
+				},
+				"container": []interface{}{
+					map[string]interface{}{
+						"name":  "app",
+						"image": "app:1.0",
+					},
+				},
+			},
+		}
+	}
+
+	out, err := expandPodSpec(spec("Always"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"restartPolicy":"Always"`) {
+		t.Fatalf("Expected the sidecar's restart policy to be sent, got %s", raw)
+	}
+	flattened, err := flattenPodSpec(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := flattened[0].(map[string]interface{})["init_container"].([]interface{})[0].(map[string]interface{})
+	if c["restart_policy"] != "Always" || c["startup_probe"] == nil {
+		t.Fatalf("Unexpected flattened sidecar: %#v", c)
+	}
+
+	// Plain init containers run to completion, probes don't apply to them
+	_, err = expandPodSpec(spec(""))
+	if err == nil || !strings.Contains(err.Error(), "log-forwarder") {
+		t.Fatalf("Expected probes of a plain init container to be rejected, got %v", err)
+	}
+}
+
+func TestCreatePod_startupProbe(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/api/v1/namespaces/default/pods" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		raw, _ := ioutil.ReadAll(r.Body)
+		json.Unmarshal(raw, &body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write(raw)
+	}))
+	defer srv.Close()
+
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	probe := []interface{}{
+		map[string]interface{}{
+			"http_get": []interface{}{
+				map[string]interface{}{"path": "/healthz", "port": "8080"},
+			},
+			"failure_threshold": 30,
+			"period_seconds":    10,
+		},
+	}
+	spec, err := expandPodSpec([]interface{}{
+		map[string]interface{}{
+			"container": []interface{}{
+				map[string]interface{}{
+					"name":          "app",
+					"image":         "app:1.0",
+					"startup_probe": probe,
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod := podWithNewerSpec{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       spec,
+	}
+	var out podWithNewerSpec
+	err = objectKinds["Pod"].create(conn, "default", &pod, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The typed client would have dropped the probe
+	sent := body["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+	if _, ok := sent["startupProbe"]; !ok {
+		t.Fatalf("Expected a startup probe to be sent, got %#v", sent)
+	}
+
+	flattened, err := flattenPodSpec(out.Spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := flattened[0].(map[string]interface{})["container"].([]interface{})[0].(map[string]interface{})
+	startupProbe, ok := c["startup_probe"].([]interface{})
+	if !ok || startupProbe[0].(map[string]interface{})["failure_threshold"] != int32(30) {
+		t.Fatalf("Unexpected flattened startup probe: %#v", c["startup_probe"])
+	}
+}
+
+func TestExpandFlattenTolerations(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"effect":             "NoSchedule",
+			"key":                "dedicated",
+			"operator":           "Equal",
+			"value":              "gpu",
+			"toleration_seconds": 0,
+		},
+		map[string]interface{}{
+			"effect":             "NoExecute",
+			"key":                "node.kubernetes.io/unreachable",
+			"operator":           "Exists",
+			"value":              "",
+			"toleration_seconds": 60,
+		},
+	}
+
+	tolerations := expandTolerations(in)
+	expected := []v1.Toleration{
+		{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptrToInt64(60)},
+	}
+	if !reflect.DeepEqual(tolerations, expected) {
+		t.Fatalf("Unexpected tolerations.\nExpected: %#v\nGiven: %#v", expected, tolerations)
+	}
+
+	// The ones added by admission must not show up as a diff
+	tolerations = append(tolerations,
+		v1.Toleration{Key: "node.kubernetes.io/not-ready", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptrToInt64(300)},
+		v1.Toleration{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: ptrToInt64(300)},
+	)
+	out := flattenTolerations(tolerations)
+	if len(out) != 2 {
+		t.Fatalf("Expected default tolerations to be left out: %#v", out)
+	}
+	if out[1].(map[string]interface{})["toleration_seconds"] != 60 {
+		t.Fatalf("Unexpected toleration: %#v", out[1])
+	}
+}
+
+func TestExpandFlattenTopologySpreadConstraints(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"max_skew":           1,
+			"topology_key":       "topology.kubernetes.io/zone",
+			"when_unsatisfiable": "DoNotSchedule",
+			"min_domains":        3,
+			"label_selector": []interface{}{
+				map[string]interface{}{
+					"match_labels": map[string]interface{}{"app": "web"},
+				},
+			},
+		},
+		map[string]interface{}{
+			"max_skew":           2,
+			"topology_key":       "kubernetes.io/hostname",
+			"when_unsatisfiable": "ScheduleAnyway",
+			"min_domains":        0,
+		},
+	}
+
+	constraints := expandTopologySpreadConstraints(in)
+	expected := []topologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: "DoNotSchedule",
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			MinDomains:        ptrToInt32(3),
+		},
+		{MaxSkew: 2, TopologyKey: "kubernetes.io/hostname", WhenUnsatisfiable: "ScheduleAnyway"},
+	}
+	if !reflect.DeepEqual(constraints, expected) {
+		t.Fatalf("Unexpected constraints.\nExpected: %#v\nGiven: %#v", expected, constraints)
+	}
+
+	raw, err := json.Marshal(podSpec{TopologySpreadConstraints: constraints})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `"topologySpreadConstraints":[{"maxSkew":1,"topologyKey":"topology.kubernetes.io/zone","whenUnsatisfiable":"DoNotSchedule","labelSelector":{"matchLabels":{"app":"web"}},"minDomains":3}`) {
+		t.Fatalf("Expected the constraints to be sent, got %s", raw)
+	}
+
+	out := flattenTopologySpreadConstraints(constraints)
+	if len(out) != 2 {
+		t.Fatalf("Unexpected flattened constraints: %#v", out)
+	}
+	c := out[0].(map[string]interface{})
+	if c["min_domains"] != 3 || c["label_selector"] == nil {
+		t.Fatalf("Unexpected flattened constraint: %#v", c)
+	}
+	if _, ok := out[1].(map[string]interface{})["min_domains"]; ok {
+		t.Fatalf("Expected unset min_domains to be left out: %#v", out[1])
+	}
+}
+
+func TestExpandFlattenProjectedVolumeSource(t *testing.T) {
+	in := &projectedVolumeSource{
+		DefaultMode: ptrToInt32(0440),
+		Sources: []volumeProjection{
+			{VolumeProjection: v1.VolumeProjection{
+				Secret: &v1.SecretProjection{
+					LocalObjectReference: v1.LocalObjectReference{Name: "credentials"},
+					Items: []v1.KeyToPath{
+						{Key: "password", Path: "db/password", Mode: ptrToInt32(0400)},
+						{Key: "username", Path: "db/username"},
+					},
+				},
+			}},
+			{VolumeProjection: v1.VolumeProjection{
+				ConfigMap: &v1.ConfigMapProjection{
+					LocalObjectReference: v1.LocalObjectReference{Name: "settings"},
+					Items:                []v1.KeyToPath{},
+					Optional:             ptrToBool(true),
+				},
+			}},
+			{VolumeProjection: v1.VolumeProjection{
+				DownwardAPI: &v1.DownwardAPIProjection{
+					Items: []v1.DownwardAPIVolumeFile{
+						{Path: "labels", FieldRef: &v1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.labels"}},
+					},
+				},
+			}},
+			{
+				ServiceAccountToken: &serviceAccountTokenProjection{
+					Audience:          "vault",
+					ExpirationSeconds: ptrToInt64(7200),
+					Path:              "token",
+				},
+			},
+		},
+	}
+
+	// Round trip through the schema, as the flattened values are typed differently
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"volume": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     volumeSchema(),
+			},
+		},
+	}
+	d := r.TestResourceData()
+	flattened, err := flattenVolumes([]volume{{Volume: v1.Volume{Name: "config"}, Projected: in}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.Set("volume", flattened)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := expandVolumes(d.Get("volume").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out[0].Projected, in) {
+		t.Fatalf("Projected volume didn't survive flattening and expanding.\nExpected: %#v\nGiven: %#v", in, out[0].Projected)
+	}
+
+	raw, err := json.Marshal(out[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"serviceAccountToken":{"audience":"vault","expirationSeconds":7200,"path":"token"}}`
+	if !strings.Contains(string(raw), expected) {
+		t.Fatalf("Expected %s in %s", expected, raw)
+	}
+}
+
+func TestExpandFlattenCSIAndEphemeralVolumes(t *testing.T) {
+	in := []volume{
+		{
+			Volume: v1.Volume{Name: "secrets-store"},
+			CSI: &csiVolumeSource{
+				Driver:               "secrets-store.csi.k8s.io",
+				ReadOnly:             ptrToBool(true),
+				VolumeAttributes:     map[string]string{"secretProviderClass": "app-secrets"},
+				NodePublishSecretRef: &v1.LocalObjectReference{Name: "provider-credentials"},
+			},
+		},
+		{
+			Volume: v1.Volume{Name: "scratch"},
+			Ephemeral: &ephemeralVolumeSource{
+				VolumeClaimTemplate: &persistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+					Spec: v1.PersistentVolumeClaimSpec{
+						AccessModes:      []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce},
+						Resources:        v1.ResourceRequirements{Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("1Gi")}},
+						StorageClassName: ptrToString("scratch"),
+					},
+				},
+			},
+		},
+	}
+
+	raw, err := json.Marshal(podSpec{Volumes: in})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`{"name":"secrets-store","csi":{"driver":"secrets-store.csi.k8s.io","readOnly":true,"volumeAttributes":{"secretProviderClass":"app-secrets"},"nodePublishSecretRef":{"name":"provider-credentials"}}}`,
+		`{"name":"scratch","ephemeral":{"volumeClaimTemplate":{"metadata":{"creationTimestamp":null,"labels":{"app":"web"}},"spec":{"accessModes":["ReadWriteOnce"],"resources":{"requests":{"storage":"1Gi"}},"storageClassName":"scratch"}}}}`,
+	} {
+		if !strings.Contains(string(raw), expected) {
+			t.Fatalf("Expected %s to be sent, got %s", expected, raw)
+		}
+	}
+
+	// Round trip through the schema, as the flattened values are typed differently
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"volume": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     volumeSchema(),
+			},
+		},
+	}
+	d := r.TestResourceData()
+	flattened, err := flattenVolumes(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = d.Set("volume", flattened)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := expandVolumes(d.Get("volume").([]interface{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out[0].CSI, in[0].CSI) {
+		t.Fatalf("CSI volume didn't survive flattening and expanding.\nExpected: %#v\nGiven: %#v", in[0].CSI, out[0].CSI)
+	}
+	template := out[1].Ephemeral.VolumeClaimTemplate
+	if !reflect.DeepEqual(template.Labels, in[1].Ephemeral.VolumeClaimTemplate.Labels) {
+		t.Fatalf("Unexpected claim template labels: %#v", template.Labels)
+	}
+	if storage := template.Spec.Resources.Requests[v1.ResourceStorage]; storage.String() != "1Gi" {
+		t.Fatalf("Unexpected claim template spec: %#v", template.Spec)
+	}
+}
+
+func TestExpandExplicitZeroValues(t *testing.T) {
+	config := func(userID, escalation interface{}) map[string]interface{} {
+		sc := map[string]interface{}{"run_as_non_root": false}
+		if userID != nil {
+			sc["run_as_user"] = userID
+			sc["run_as_group"] = userID
+			sc["fs_group"] = userID
+		}
+		csc := map[string]interface{}{"privileged": false}
+		if userID != nil {
+			csc["run_as_user"] = userID
+			csc["run_as_group"] = userID
+		}
+		if escalation != nil {
+			csc["allow_privilege_escalation"] = escalation
+		}
+		return map[string]interface{}{
+			"metadata": []interface{}{map[string]interface{}{"name": "test"}},
+			"spec": []interface{}{
+				map[string]interface{}{
+					"security_context": []interface{}{sc},
+					"container": []interface{}{
+						map[string]interface{}{
+							"name":             "app",
+							"image":            "nginx",
+							"security_context": []interface{}{csc},
+						},
+					},
+				},
+			},
+		}
+	}
+	r := resourceKubernetesPod()
+
+	cases := []struct {
+		UserID     interface{}
+		Expected   *int64
+		Escalation interface{}
+		Allowed    *bool
+	}{
+		{nil, nil, nil, nil},
+		{0, ptrToInt64(0), false, ptrToBool(false)},
+		{1000, ptrToInt64(1000), true, ptrToBool(true)},
+	}
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, r.Schema, config(tc.UserID, tc.Escalation))
+		spec, err := expandPodSpec(d.Get("spec").([]interface{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expandExplicitZeroValues(d, "spec.0.", &spec)
+
+		for name, got := range map[string]*int64{
+			"fs_group":               spec.SecurityContext.FSGroup,
+			"run_as_user":            spec.SecurityContext.RunAsUser,
+			"run_as_group":           spec.SecurityContext.RunAsGroup,
+			"container run_as_user":  spec.Containers[0].SecurityContext.RunAsUser,
+			"container run_as_group": spec.Containers[0].SecurityContext.RunAsGroup,
+		} {
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("Unexpected %s for %v, expected %v, given %v", name, tc.UserID, tc.Expected, got)
+			}
+		}
+		// Unset leaves it to the container runtime, privileged containers can't send false
+		if got := spec.Containers[0].SecurityContext.AllowPrivilegeEscalation; !reflect.DeepEqual(got, tc.Allowed) {
+			t.Fatalf("Unexpected allow_privilege_escalation for %v, expected %v, given %v", tc.Escalation, tc.Allowed, got)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	rbac "k8s.io/kubernetes/pkg/apis/rbac/v1beta1"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+// rbacGroupVersions lists API versions serving RBAC objects, newest first.
+// The vendored client only knows rbac/v1beta1 which newer servers dropped.
+var rbacGroupVersions = []string{"rbac.authorization.k8s.io/v1", "rbac.authorization.k8s.io/v1beta1"}
+
+// rbacPath builds the path of RBAC objects of the given resource,
+// namespaced when namespace isn't empty and a single object when name isn't
+func rbacPath(gv, resource, namespace, name string) []string {
+	path := []string{"/apis", gv}
+	if namespace != "" {
+		path = append(path, "namespaces", namespace)
+	}
+	path = append(path, resource)
+	if name != "" {
+		path = append(path, name)
+	}
+	return path
+}
+
+// getRbacObject fetches an RBAC object from the first API version
+// the server serves and decodes it into out
+func getRbacObject(conn *kubernetes.Clientset, resource, namespace, name string, out interface{}) error {
+	var err error
+	for _, gv := range rbacGroupVersions {
+		var raw []byte
+		raw, err = conn.CoreV1().RESTClient().Get().
+			AbsPath(rbacPath(gv, resource, namespace, name)...).
+			Do().
+			Raw()
+		if err != nil {
+			if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+				log.Printf("[DEBUG] %s %s/%s not found in %s", resource, namespace, name, gv)
+				continue
+			}
+			return err
+		}
+
+		err = json.Unmarshal(raw, out)
+		if err != nil {
+			return fmt.Errorf("Failed to decode %s %s/%s: %s", resource, namespace, name, err)
+		}
+		return nil
+	}
+	return err
+}
+
+// listRbacObjects lists RBAC objects from the first API version
+// the server serves and decodes the list into out.
+// Namespaced resources are listed across all namespaces when namespace is empty.
+// The list is fetched in chunks of objectListPageSize like listObjectMetas.
+func listRbacObjects(conn *kubernetes.Clientset, resource, namespace string, out interface{}) error {
+	items := make([]json.RawMessage, 0)
+	continueToken := ""
+	// The API version is picked with the first page and kept for the others
+	gvs := rbacGroupVersions
+	for {
+		var raw []byte
+		var err error
+		for _, gv := range gvs {
+			r := conn.CoreV1().RESTClient().Get().
+				AbsPath(rbacPath(gv, resource, namespace, "")...).
+				Param("limit", strconv.Itoa(objectListPageSize))
+			if continueToken != "" {
+				r = r.Param("continue", continueToken)
+			}
+			raw, err = r.Do().Raw()
+			if err != nil {
+				if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
+					log.Printf("[DEBUG] %s not served by %s", resource, gv)
+					continue
+				}
+				return err
+			}
+			gvs = []string{gv}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		var page struct {
+			Metadata struct {
+				Continue string `json:"continue"`
+			} `json:"metadata"`
+			Items []json.RawMessage `json:"items"`
+		}
+		err = json.Unmarshal(raw, &page)
+		if err != nil {
+			return fmt.Errorf("Failed to decode %s: %s", resource, err)
+		}
+		log.Printf("[DEBUG] Received %d %s", len(page.Items), resource)
+		items = append(items, page.Items...)
+		if page.Metadata.Continue == "" {
+			break
+		}
+		continueToken = page.Metadata.Continue
+	}
+
+	raw, err := json.Marshal(map[string]interface{}{"items": items})
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(raw, out)
+	if err != nil {
+		return fmt.Errorf("Failed to decode %s: %s", resource, err)
+	}
+	return nil
+}
+
+// Flatteners
+
+func flattenPolicyRules(in []rbac.PolicyRule) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, r := range in {
+		att[i] = map[string]interface{}{
+			"verbs":             r.Verbs,
+			"api_groups":        r.APIGroups,
+			"resources":         r.Resources,
+			"resource_names":    r.ResourceNames,
+			"non_resource_urls": r.NonResourceURLs,
+		}
+	}
+	return att
+}
+
+func flattenRoleRef(in rbac.RoleRef) []interface{} {
+	att := map[string]interface{}{
+		"api_group": in.APIGroup,
+		"kind":      in.Kind,
+		"name":      in.Name,
+	}
+	return []interface{}{att}
+}
+
+func flattenSubjects(in []rbac.Subject) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, s := range in {
+		att[i] = map[string]interface{}{
+			"kind":      s.Kind,
+			"api_group": s.APIGroup,
+			"name":      s.Name,
+			"namespace": s.Namespace,
+		}
+	}
+	return att
+}
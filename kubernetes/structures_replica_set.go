@@ -0,0 +1,56 @@
+package kubernetes
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/extensions/v1beta1"
+)
+
+// replicaSetSpec is a replica set spec with a podTemplateSpec
+type replicaSetSpec struct {
+	v1beta1.ReplicaSetSpec
+	Template podTemplateSpec `json:"template,omitempty"`
+}
+
+func flattenReplicaSetSpec(in replicaSetSpec) ([]interface{}, error) {
+	att := make(map[string]interface{})
+	att["min_ready_seconds"] = in.MinReadySeconds
+
+	if in.Replicas != nil {
+		att["replicas"] = *in.Replicas
+	}
+
+	if in.Selector != nil {
+		att["selector"] = flattenLabelSelector(in.Selector)
+	}
+
+	podSpec, err := flattenPodSpec(in.Template.Spec)
+	if err != nil {
+		return nil, err
+	}
+	att["template"] = podSpec
+
+	return []interface{}{att}, nil
+}
+
+func expandReplicaSetSpec(rs []interface{}) (replicaSetSpec, error) {
+	obj := replicaSetSpec{}
+	if len(rs) == 0 || rs[0] == nil {
+		return obj, nil
+	}
+	in := rs[0].(map[string]interface{})
+	obj.MinReadySeconds = int32(in["min_ready_seconds"].(int))
+	obj.Replicas = ptrToInt32(int32(in["replicas"].(int)))
+	obj.Selector = expandLabelSelector(in["selector"].([]interface{}))
+	podSpec, err := expandPodSpec(in["template"].([]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Template = podTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: obj.Selector.MatchLabels,
+		},
+		Spec: podSpec,
+	}
+
+	return obj, nil
+}
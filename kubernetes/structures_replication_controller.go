@@ -5,7 +5,14 @@ import (
 	"k8s.io/kubernetes/pkg/api/v1"
 )
 
-func flattenReplicationControllerSpec(in v1.ReplicationControllerSpec) ([]interface{}, error) {
+// replicationControllerSpec is a replication controller spec
+// with a podTemplateSpec
+type replicationControllerSpec struct {
+	v1.ReplicationControllerSpec
+	Template *podTemplateSpec `json:"template,omitempty"`
+}
+
+func flattenReplicationControllerSpec(in replicationControllerSpec) ([]interface{}, error) {
 	att := make(map[string]interface{})
 	att["min_ready_seconds"] = in.MinReadySeconds
 
@@ -23,8 +30,8 @@ func flattenReplicationControllerSpec(in v1.ReplicationControllerSpec) ([]interf
 	return []interface{}{att}, nil
 }
 
-func expandReplicationControllerSpec(rc []interface{}) (v1.ReplicationControllerSpec, error) {
-	obj := v1.ReplicationControllerSpec{}
+func expandReplicationControllerSpec(rc []interface{}) (replicationControllerSpec, error) {
+	obj := replicationControllerSpec{}
 	if len(rc) == 0 || rc[0] == nil {
 		return obj, nil
 	}
@@ -36,7 +43,7 @@ func expandReplicationControllerSpec(rc []interface{}) (v1.ReplicationController
 	if err != nil {
 		return obj, err
 	}
-	obj.Template = &v1.PodTemplateSpec{
+	obj.Template = &podTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
 			Labels: obj.Selector,
 		},
@@ -0,0 +1,94 @@
+package kubernetes
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	api "k8s.io/kubernetes/pkg/api/v1"
+)
+
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth,omitempty"`
+}
+
+// Expanders
+
+func expandDockerRegistry(l []interface{}) (string, error) {
+	if len(l) == 0 || l[0] == nil {
+		return "", nil
+	}
+	in := l[0].(map[string]interface{})
+
+	entry := dockerConfigEntry{
+		Username: in["username"].(string),
+		Password: in["password"].(string),
+		Email:    in["email"].(string),
+	}
+	if entry.Username != "" || entry.Password != "" {
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(entry.Username + ":" + entry.Password))
+	}
+
+	cfg := dockerConfigJSON{
+		Auths: map[string]dockerConfigEntry{
+			in["server"].(string): entry,
+		},
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Flatteners
+
+func flattenDockerRegistry(in string, server string) ([]interface{}, error) {
+	var cfg dockerConfigJSON
+	err := json.Unmarshal([]byte(in), &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode %s: %s", api.DockerConfigJsonKey, err)
+	}
+
+	if len(cfg.Auths) == 0 {
+		return []interface{}{}, nil
+	}
+
+	// Prefer the registry we wrote, fall back to whatever is there
+	// so that a changed server shows up as a diff
+	entry, ok := cfg.Auths[server]
+	if !ok {
+		for k, v := range cfg.Auths {
+			server, entry = k, v
+			break
+		}
+	}
+
+	att := map[string]interface{}{
+		"server":   server,
+		"username": entry.Username,
+		"password": entry.Password,
+		"email":    entry.Email,
+	}
+	return []interface{}{att}, nil
+}
+
+// validateTLSKeyPair checks that the private key belongs to the certificate
+func validateTLSKeyPair(crt, key string) error {
+	if crt == "" || key == "" {
+		return fmt.Errorf("tls_crt and tls_key must be set together")
+	}
+	_, err := tls.X509KeyPair([]byte(crt), []byte(key))
+	if err != nil {
+		return fmt.Errorf("Invalid tls_crt and tls_key: %s", err)
+	}
+	return nil
+}
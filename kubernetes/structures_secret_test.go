@@ -0,0 +1,72 @@
+package kubernetes
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func TestExpandFlattenDockerRegistry(t *testing.T) {
+	in := []interface{}{
+		map[string]interface{}{
+			"server":   "registry.example.com",
+			"username": "admin",
+			"password": "password",
+			"email":    "",
+		},
+	}
+
+	cfg, err := expandDockerRegistry(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"auths":{"registry.example.com":{"username":"admin","password":"password","auth":"YWRtaW46cGFzc3dvcmQ="}}}`
+	if cfg != expected {
+		t.Fatalf("Unexpected docker config.\nExpected: %s\nGiven:    %s", expected, cfg)
+	}
+
+	out, err := flattenDockerRegistry(cfg, "registry.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Fatalf("Unexpected flattened registry.\nExpected: %#v\nGiven:    %#v", in, out)
+	}
+
+	// The registry was changed outside of Terraform
+	out, err = flattenDockerRegistry(cfg, "other.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out[0].(map[string]interface{})["server"] != "registry.example.com" {
+		t.Fatalf("Expected the server found in the secret, got %#v", out)
+	}
+
+	_, err = flattenDockerRegistry("not json", "registry.example.com")
+	if err == nil {
+		t.Fatal("Expected invalid docker config to fail")
+	}
+}
+
+func TestValidateTLSKeyPair(t *testing.T) {
+	read := func(name string) string {
+		b, err := ioutil.ReadFile("test-fixtures/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	if err := validateTLSKeyPair(read("tls.crt"), read("tls.key")); err != nil {
+		t.Fatalf("Expected matching key pair, got %q", err)
+	}
+	if err := validateTLSKeyPair(read("tls-ec.crt"), read("tls-ec.key")); err != nil {
+		t.Fatalf("Expected matching key pair, got %q", err)
+	}
+	if err := validateTLSKeyPair(read("tls.crt"), read("tls-ec.key")); err == nil {
+		t.Fatal("Expected key of another certificate to fail")
+	}
+	if err := validateTLSKeyPair(read("tls.crt"), ""); err == nil {
+		t.Fatal("Expected missing key to fail")
+	}
+}
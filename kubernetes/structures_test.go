@@ -2,7 +2,10 @@ package kubernetes
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
+
+	api "k8s.io/kubernetes/pkg/api/v1"
 )
 
 func TestIsInternalKey(t *testing.T) {
@@ -30,3 +33,90 @@ func TestIsInternalKey(t *testing.T) {
 		})
 	}
 }
+
+func TestMeshInjection(t *testing.T) {
+	testCases := []struct {
+		Provider    string
+		Enabled     bool
+		Annotations map[string]string
+	}{
+		{"istio", true, map[string]string{"sidecar.istio.io/inject": "true"}},
+		{"istio", false, map[string]string{"sidecar.istio.io/inject": "false"}},
+		{"linkerd", true, map[string]string{"linkerd.io/inject": "enabled"}},
+		{"linkerd", false, map[string]string{"linkerd.io/inject": "disabled"}},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			in := []interface{}{map[string]interface{}{
+				"provider": tc.Provider,
+				"enabled":  tc.Enabled,
+			}}
+			annotations := expandMeshInjection(in, map[string]string{"other": "value"})
+			tc.Annotations["other"] = "value"
+			if !reflect.DeepEqual(annotations, tc.Annotations) {
+				t.Fatalf("Expected annotations %q, given %q", tc.Annotations, annotations)
+			}
+			out := flattenMeshInjection(annotations)
+			if !reflect.DeepEqual(out, in) {
+				t.Fatalf("Expected mesh_injection %#v, given %#v", in, out)
+			}
+			if !reflect.DeepEqual(annotations, map[string]string{"other": "value"}) {
+				t.Fatalf("Expected injection annotation to be removed, given %q", annotations)
+			}
+		})
+	}
+}
+
+func TestMergeStringMaps(t *testing.T) {
+	testCases := []struct {
+		Current  map[string]string
+		Prev     map[string]string
+		Next     map[string]string
+		Expected map[string]string
+	}{
+		{map[string]string{"kubelet": "a"}, nil, map[string]string{"tf": "b"}, map[string]string{"kubelet": "a", "tf": "b"}},
+		{map[string]string{"kubelet": "a", "tf": "b"}, map[string]string{"tf": "b"}, map[string]string{"tf": "c"}, map[string]string{"kubelet": "a", "tf": "c"}},
+		{map[string]string{"kubelet": "a", "tf": "b"}, map[string]string{"tf": "b"}, nil, map[string]string{"kubelet": "a"}},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			out := mergeStringMaps(tc.Current, tc.Prev, tc.Next)
+			if !reflect.DeepEqual(out, tc.Expected) {
+				t.Fatalf("Expected %q, given %q", tc.Expected, out)
+			}
+		})
+	}
+}
+
+func TestMergeNodeTaints(t *testing.T) {
+	cloud := api.Taint{Key: "cloud", Effect: api.TaintEffectNoSchedule}
+	managed := api.Taint{Key: "dedicated", Value: "one", Effect: api.TaintEffectNoSchedule}
+	updated := api.Taint{Key: "dedicated", Value: "two", Effect: api.TaintEffectNoSchedule}
+
+	out := mergeNodeTaints([]api.Taint{cloud, managed}, []api.Taint{managed}, []api.Taint{updated})
+	if !reflect.DeepEqual(out, []api.Taint{cloud, updated}) {
+		t.Fatalf("Expected managed taint to be updated, given %#v", out)
+	}
+	out = mergeNodeTaints(out, []api.Taint{updated}, nil)
+	if !reflect.DeepEqual(out, []api.Taint{cloud}) {
+		t.Fatalf("Expected only the cloud taint to be left, given %#v", out)
+	}
+}
+
+func TestCheckIgnoredKeys(t *testing.T) {
+	ignored := []string{"external"}
+	data := map[string]interface{}{"one": "first"}
+	if err := checkIgnoredKeys(ignored, data); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	binary := map[string]interface{}{"external": "AAEC/w=="}
+	if err := checkIgnoredKeys(ignored, data, binary); err == nil {
+		t.Fatal("Expected an error for an ignored key being set")
+	}
+
+	out := withoutKeys(map[string]interface{}{"one": "first", "external": "x"}, ignored)
+	expected := map[string]interface{}{"one": "first"}
+	if !reflect.DeepEqual(out, expected) {
+		t.Fatalf("Expected %q, given %q", expected, out)
+	}
+}
@@ -0,0 +1,62 @@
+package kubernetes
+
+import (
+	"log"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Messages of network errors which lost their type on the way up,
+// e.g. by being formatted into another error
+var unreachableMessages = []string{
+	"connection refused",
+	"no such host",
+	"network is unreachable",
+	"no route to host",
+	"i/o timeout",
+}
+
+// isUnreachableError tells whether the error means the API server
+// couldn't be reached at all, as opposed to it rejecting the request
+func isUnreachableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+	switch err.(type) {
+	case *net.OpError, *net.DNSError:
+		return true
+	}
+
+	msg := err.Error()
+	for _, m := range unreachableMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// withUnreachableSkip makes destroying the resource succeed when the cluster
+// can't be reached and skip_unreachable_on_destroy is set, e.g. because
+// the cluster itself has been destroyed already
+func withUnreachableSkip(r *schema.Resource) {
+	del := r.Delete
+	if del == nil {
+		return
+	}
+	r.Delete = func(d *schema.ResourceData, meta interface{}) error {
+		err := del(d, meta)
+		if isUnreachableError(err) && getProviderOptions(meta).skipUnreachableOnDestroy {
+			log.Printf("[WARN] Cluster is unreachable, removing %s from state: %s", d.Id(), err)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+}
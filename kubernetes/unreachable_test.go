@@ -0,0 +1,66 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	restclient "k8s.io/client-go/rest"
+	kubernetes "k8s.io/kubernetes/pkg/client/clientset_generated/clientset"
+)
+
+func TestIsUnreachableError(t *testing.T) {
+	// A server which is gone
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+	conn, err := kubernetes.NewForConfig(&restclient.Config{Host: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unreachable := conn.CoreV1().ConfigMaps("default").Delete("test", &metav1.DeleteOptions{})
+
+	cases := []struct {
+		Err      error
+		Expected bool
+	}{
+		{nil, false},
+		{errors.New("something went wrong"), false},
+		{apierrors.NewNotFound(k8sschema.GroupResource{Resource: "pods"}, "web"), false},
+		{unreachable, true},
+		{fmt.Errorf("Failed to delete: %s", unreachable), true},
+	}
+	for i, tc := range cases {
+		if isUnreachableError(tc.Err) != tc.Expected {
+			t.Fatalf("%d: expected %t for %#v", i, tc.Expected, tc.Err)
+		}
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Delete: func(d *schema.ResourceData, meta interface{}) error {
+			return meta.(*kubernetes.Clientset).CoreV1().ConfigMaps("default").Delete("test", &metav1.DeleteOptions{})
+		},
+	}
+	withUnreachableSkip(r)
+
+	d := r.TestResourceData()
+	d.SetId("default/test")
+	if err := r.Delete(d, conn); err == nil {
+		t.Fatal("Expected unreachable cluster to fail the destroy by default")
+	}
+
+	setProviderOptions(conn, providerOptions{skipUnreachableOnDestroy: true})
+	defer setProviderOptions(conn, providerOptions{})
+	if err := r.Delete(d, conn); err != nil {
+		t.Fatalf("Expected unreachable cluster to be skipped, got %q", err)
+	}
+	if d.Id() != "" {
+		t.Fatal("Expected resource to be removed from state")
+	}
+}
@@ -1,14 +1,19 @@
 package kubernetes
 
 import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"k8s.io/apimachinery/pkg/api/resource"
 	apiValidation "k8s.io/apimachinery/pkg/api/validation"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	utilValidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -41,16 +46,41 @@ func validateName(value interface{}, key string) (ws []string, es []error) {
 	return
 }
 
-func validateGenerateName(value interface{}, key string) (ws []string, es []error) {
-	v := value.(string)
+// metadataNameFuncs holds name rules of kinds which differ from
+// the default DNS subdomain (max. 253 characters)
+var metadataNameFuncs = map[string]apiValidation.ValidateNameFunc{
+	"namespace": apiValidation.NameIsDNSLabel,
+	"service":   apiValidation.NameIsDNS1035Label,
+	// Name of the job ends up in the job-name label of its pods
+	// and label values are limited to 63 characters
+	"job": apiValidation.NameIsDNSLabel,
+}
 
-	errors := apiValidation.NameIsDNSLabel(v, true)
-	if len(errors) > 0 {
-		for _, err := range errors {
+func metadataNameFunc(objectName string) apiValidation.ValidateNameFunc {
+	if f, ok := metadataNameFuncs[objectName]; ok {
+		return f
+	}
+	return apiValidation.NameIsDNSSubdomain
+}
+
+func validateMetadataName(objectName string) schema.SchemaValidateFunc {
+	nameFunc := metadataNameFunc(objectName)
+	return func(value interface{}, key string) (ws []string, es []error) {
+		for _, err := range nameFunc(value.(string), false) {
 			es = append(es, fmt.Errorf("%s %s", key, err))
 		}
+		return
+	}
+}
+
+func validateMetadataGenerateName(objectName string) schema.SchemaValidateFunc {
+	nameFunc := metadataNameFunc(objectName)
+	return func(value interface{}, key string) (ws []string, es []error) {
+		for _, err := range nameFunc(value.(string), true) {
+			es = append(es, fmt.Errorf("%s %s", key, err))
+		}
+		return
 	}
-	return
 }
 
 func validateLabels(value interface{}, key string) (ws []string, es []error) {
@@ -82,6 +112,14 @@ func validatePortName(value interface{}, key string) (ws []string, es []error) {
 	}
 	return
 }
+func validatePortRange(value interface{}, key string) (ws []string, es []error) {
+	_, err := utilnet.ParsePortRange(value.(string))
+	if err != nil {
+		es = append(es, fmt.Errorf("%s %s", key, err))
+	}
+	return
+}
+
 func validatePortNumOrName(value interface{}, key string) (ws []string, es []error) {
 	switch value.(type) {
 	case string:
@@ -120,6 +158,16 @@ func validateResourceList(value interface{}, key string) (ws []string, es []erro
 	return
 }
 
+func validateBase64EncodedMap(value interface{}, key string) (ws []string, es []error) {
+	m := value.(map[string]interface{})
+	for k, v := range m {
+		if _, err := base64.StdEncoding.DecodeString(v.(string)); err != nil {
+			es = append(es, fmt.Errorf("%s.%s: must be base64 encoded: %s", key, k, err))
+		}
+	}
+	return
+}
+
 func validateResourceQuantity(value interface{}, key string) (ws []string, es []error) {
 	if v, ok := value.(string); ok {
 		_, err := resource.ParseQuantity(v)
@@ -138,6 +186,65 @@ func validatePositiveInteger(value interface{}, key string) (ws []string, es []e
 	return
 }
 
+func validateNonNegativeInteger(value interface{}, key string) (ws []string, es []error) {
+	v := value.(int)
+	if v < 0 {
+		es = append(es, fmt.Errorf("%s must be greater than or equal to 0", key))
+	}
+	return
+}
+
+func validateTokenExpirationSeconds(value interface{}, key string) (ws []string, es []error) {
+	v := value.(int)
+	if v < 600 {
+		es = append(es, fmt.Errorf("%s must be at least 600", key))
+	}
+	return
+}
+
+func validatePEMCertificate(value interface{}, key string) (ws []string, es []error) {
+	rest := []byte(value.(string))
+	found := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		found = true
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			es = append(es, fmt.Errorf("%s contains an invalid certificate: %s", key, err))
+			return
+		}
+	}
+	if !found {
+		es = append(es, fmt.Errorf("%s must contain at least one PEM-encoded certificate", key))
+	}
+	return
+}
+
+func validatePEMPrivateKey(value interface{}, key string) (ws []string, es []error) {
+	block, _ := pem.Decode([]byte(value.(string)))
+	if block == nil || !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+		es = append(es, fmt.Errorf("%s must be a PEM-encoded private key", key))
+		return
+	}
+	if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return
+	}
+	if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return
+	}
+	if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return
+	}
+	es = append(es, fmt.Errorf("%s contains an invalid or unsupported private key", key))
+	return
+}
+
 func validateDNSPolicy(value interface{}, key string) (ws []string, es []error) {
 	v := value.(string)
 	if v != "ClusterFirst" && v != "Default" {
@@ -157,12 +264,42 @@ func validateRestartPolicy(value interface{}, key string) (ws []string, es []err
 	return
 }
 
-func validateTerminationGracePeriodSeconds(value interface{}, key string) (ws []string, es []error) {
-	v := value.(int)
-	if v < 0 {
-		es = append(es, fmt.Errorf("%s must be greater than or equal to 0", key))
+// parseDuration parses a Go duration (e.g. `1m30s`) or a number of seconds
+func parseDuration(v string) (time.Duration, error) {
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, nil
+	}
+	return time.ParseDuration(v)
+}
+
+// validateDuration checks a duration given in seconds (integer fields) or as
+// a Go duration or number of seconds (string fields) is within bounds, no upper bound when max is 0
+func validateDuration(min, max time.Duration) schema.SchemaValidateFunc {
+	return func(value interface{}, key string) (ws []string, es []error) {
+		var d time.Duration
+		switch v := value.(type) {
+		case int:
+			d = time.Duration(v) * time.Second
+		case string:
+			var err error
+			d, err = parseDuration(v)
+			if err != nil {
+				es = append(es, fmt.Errorf("%s must be a duration like 30s or 5m, or a number of seconds, got %q", key, v))
+				return
+			}
+		default:
+			es = append(es, fmt.Errorf("%s must be a duration, got %T", key, value))
+			return
+		}
+
+		if d < min {
+			es = append(es, fmt.Errorf("%s must be at least %s, got %s", key, min, d))
+		}
+		if max > 0 && d > max {
+			es = append(es, fmt.Errorf("%s must be at most %s, got %s", key, max, d))
+		}
+		return
 	}
-	return
 }
 
 func validateModeBits(value interface{}, key string) (ws []string, es []error) {
@@ -173,6 +310,14 @@ func validateModeBits(value interface{}, key string) (ws []string, es []error) {
 	return
 }
 
+func validateSchedulingWeight(value interface{}, key string) (ws []string, es []error) {
+	v := value.(int)
+	if v < 1 || v > 100 {
+		es = append(es, fmt.Errorf("%s must be in the range 1-100, got %d", key, v))
+	}
+	return
+}
+
 func validateAttributeValueDoesNotContain(searchString string) schema.SchemaValidateFunc {
 	return func(v interface{}, k string) (ws []string, errors []error) {
 		input := v.(string)
@@ -1,7 +1,10 @@
 package kubernetes
 
 import (
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateModeBits(t *testing.T) {
@@ -25,3 +28,138 @@ func TestValidateModeBits(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateSchedulingWeight(t *testing.T) {
+	for _, weight := range []int{1, 50, 100} {
+		_, es := validateSchedulingWeight(weight, "weight")
+		if len(es) > 0 {
+			t.Fatalf("Expected %d to be valid: %#v", weight, es)
+		}
+	}
+	for _, weight := range []int{-1, 0, 101} {
+		_, es := validateSchedulingWeight(weight, "weight")
+		if len(es) == 0 {
+			t.Fatalf("Expected %d to be invalid", weight)
+		}
+	}
+}
+
+func TestValidateMetadataName(t *testing.T) {
+	long := strings.Repeat("a", 64)
+	validCases := map[string][]string{
+		"config map": {"foo", "foo.bar", "foo-bar", long},
+		"namespace":  {"foo", "foo-bar", "1foo"},
+		"service":    {"foo", "foo-bar"},
+	}
+	for kind, names := range validCases {
+		for _, name := range names {
+			_, es := validateMetadataName(kind)(name, "name")
+			if len(es) > 0 {
+				t.Fatalf("Expected %q to be a valid %s name: %#v", name, kind, es)
+			}
+		}
+	}
+
+	invalidCases := map[string][]string{
+		"config map": {"Foo", "foo_bar", "-foo", strings.Repeat("a", 254)},
+		"namespace":  {"foo.bar", long},
+		"service":    {"foo.bar", "1foo", long},
+		"job":        {long},
+	}
+	for kind, names := range invalidCases {
+		for _, name := range names {
+			_, es := validateMetadataName(kind)(name, "name")
+			if len(es) == 0 {
+				t.Fatalf("Expected %q to be an invalid %s name", name, kind)
+			}
+		}
+	}
+}
+
+func TestValidateMetadataGenerateName(t *testing.T) {
+	validCases := map[string][]string{
+		"config map": {"foo-", "foo.bar-"},
+		"service":    {"foo-"},
+	}
+	for kind, names := range validCases {
+		for _, name := range names {
+			_, es := validateMetadataGenerateName(kind)(name, "generate_name")
+			if len(es) > 0 {
+				t.Fatalf("Expected %q to be a valid %s generate_name: %#v", name, kind, es)
+			}
+		}
+	}
+
+	invalidCases := map[string][]string{
+		"config map": {"Foo-", "foo_"},
+		"service":    {"foo.bar-", "1foo-"},
+	}
+	for kind, names := range invalidCases {
+		for _, name := range names {
+			_, es := validateMetadataGenerateName(kind)(name, "generate_name")
+			if len(es) == 0 {
+				t.Fatalf("Expected %q to be an invalid %s generate_name", name, kind)
+			}
+		}
+	}
+}
+
+func TestValidatePEM(t *testing.T) {
+	read := func(name string) string {
+		b, err := ioutil.ReadFile("test-fixtures/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	crt, key := read("tls.crt"), read("tls.key")
+	ecCrt, ecKey := read("tls-ec.crt"), read("tls-ec.key")
+
+	for _, v := range []string{crt, ecCrt, crt + ecCrt} {
+		if _, es := validatePEMCertificate(v, "tls_crt"); len(es) > 0 {
+			t.Fatalf("Expected valid certificate: %#v", es)
+		}
+	}
+	for _, v := range []string{"", "not a certificate", key, strings.Replace(crt, "MII", "XXX", 1)} {
+		if _, es := validatePEMCertificate(v, "tls_crt"); len(es) == 0 {
+			t.Fatalf("Expected %q to be an invalid certificate", v)
+		}
+	}
+
+	for _, v := range []string{key, ecKey} {
+		if _, es := validatePEMPrivateKey(v, "tls_key"); len(es) > 0 {
+			t.Fatalf("Expected valid private key: %#v", es)
+		}
+	}
+	for _, v := range []string{"", "not a key", crt} {
+		if _, es := validatePEMPrivateKey(v, "tls_key"); len(es) == 0 {
+			t.Fatalf("Expected %q to be an invalid private key", v)
+		}
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	validate := validateDuration(time.Second, 10*time.Minute)
+
+	validCases := []interface{}{1, 600, "1", "30s", "1m30s", "10m"}
+	for _, v := range validCases {
+		_, es := validate(v, "period")
+		if len(es) > 0 {
+			t.Fatalf("Expected %#v to be valid: %#v", v, es)
+		}
+	}
+
+	invalidCases := []interface{}{0, -1, 601, "0", "500ms", "11m", "5 minutes", ""}
+	for _, v := range invalidCases {
+		_, es := validate(v, "period")
+		if len(es) == 0 {
+			t.Fatalf("Expected %#v to be invalid", v)
+		}
+	}
+
+	// No upper bound
+	_, es := validateDuration(0, 0)(86400, "grace_period_seconds")
+	if len(es) > 0 {
+		t.Fatalf("Expected unbounded duration to be valid: %#v", es)
+	}
+}